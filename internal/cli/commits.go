@@ -0,0 +1,162 @@
+package cli
+
+import (
+	"context"
+	"fmt"
+	"path/filepath"
+	"strings"
+	"text/template"
+
+	"github.com/b-jonathan/taco/internal/fsutil"
+	"github.com/b-jonathan/taco/internal/git"
+	"github.com/b-jonathan/taco/internal/hooks"
+	"github.com/b-jonathan/taco/internal/logx"
+)
+
+const (
+	defaultCommitMessage      = "chore: scaffold {{ .Name }} with taco"
+	defaultStackCommitMessage = "feat({{ .Slot }}): add {{ .Stack }}"
+)
+
+// commitData is what --commit-message and --stack-commit-message templates see.
+type commitData struct {
+	Name   string
+	Stacks map[string]string // selected stacks by slot
+	Slot   string            // per-stack commits only
+	Stack  string
+}
+
+// slotPaths are the folders (or files) owned by a slot, in commit order. Slots
+// that only add to another slot's folder, like database, cache and auth, end up in
+// that slot's commit.
+var slotPaths = []struct {
+	slot  string
+	paths []string
+}{
+	{"frontend", []string{"frontend"}},
+	{"backend", []string{"backend"}},
+	{"infra", []string{"docker-compose.yml"}},
+	{"ci", []string{filepath.Join(".github", "workflows")}},
+}
+
+func gitOptions(params InitParams) git.Options {
+	return git.Options{
+		Branch:     params.DefaultBranch,
+		Author:     params.GitAuthor,
+		Committer:  params.GitCommitter,
+		Sign:       params.Sign,
+		SigningKey: params.SigningKey,
+		// the scaffold is committed as generated, before anyone has run lint-fix
+		NoVerify: params.Hooks != "" && params.Hooks != "none",
+	}
+}
+
+// initLocalRepo initializes the repository and installs the pre-commit hooks.
+// Without a remote it also commits the scaffold; with one, the push does.
+// Inside an enclosing repository it commits only with an explicit --git.
+// Problems only warn, since the scaffold itself is complete at this point.
+func initLocalRepo(ctx context.Context, projectRoot string, params InitParams, stack map[string]string) {
+	opts := gitOptions(params)
+	if err := git.Init(ctx, projectRoot, opts); err != nil {
+		logx.Warnf("git init: %v", err)
+		return
+	}
+
+	top, enclosing := git.Enclosing(ctx, projectRoot)
+	if enclosing && params.Hooks != "none" {
+		logx.Warnf("not installing %s hooks into the enclosing repository at %s", params.Hooks, top)
+	} else if err := hooks.Install(ctx, projectRoot, params.Name, params.Hooks); err != nil {
+		logx.Warnf("install %s hooks: %v", params.Hooks, err)
+	}
+
+	if params.UseGitHub {
+		return
+	}
+	// don't commit onto the enclosing repository's branch unless asked to
+	if enclosing && !params.GitExplicit {
+		fmt.Printf("%s is inside the repository at %s; left uncommitted. Commit it yourself or rerun with --git\n", projectRoot, top)
+		return
+	}
+	commits, err := scaffoldCommits(projectRoot, params, stack)
+	if err == nil {
+		err = git.CommitAll(ctx, projectRoot, commits, opts)
+	}
+	if err != nil {
+		logx.Warnf("commit scaffold: %v; the files are left uncommitted", err)
+		return
+	}
+	if enclosing {
+		fmt.Printf("Committed %s to the repository at %s\n", projectRoot, top)
+	} else {
+		fmt.Printf("Committed scaffold on %s\n", opts.Branch)
+	}
+}
+
+func parseMessage(tmpl string) (*template.Template, error) {
+	t, err := template.New("commit").Option("missingkey=error").Parse(tmpl)
+	if err != nil {
+		return nil, fmt.Errorf("parse commit message template: %w", err)
+	}
+	return t, nil
+}
+
+func renderMessage(tmpl string, data commitData) (string, error) {
+	t, err := parseMessage(tmpl)
+	if err != nil {
+		return "", err
+	}
+	var b strings.Builder
+	if err := t.Execute(&b, data); err != nil {
+		return "", fmt.Errorf("render commit message: %w", err)
+	}
+	return strings.TrimSpace(b.String()), nil
+}
+
+// scaffoldCommits plans the commits for the scaffold: one commit with
+// everything, or with --commit-per-stack one per slot folder followed by a
+// commit with the remaining project files.
+func scaffoldCommits(projectRoot string, params InitParams, stack map[string]string) ([]git.Commit, error) {
+	// every slot has an entry so templates can name any of them; unselected
+	// ones read "none"
+	data := commitData{Name: params.Name, Stacks: map[string]string{"ci": "none"}}
+	for _, sl := range slots {
+		data.Stacks[sl.key] = "none"
+	}
+	for slot, s := range stack {
+		if s != "" {
+			data.Stacks[slot] = s
+		}
+	}
+
+	var commits []git.Commit
+	if params.CommitPerStack {
+		for _, sp := range slotPaths {
+			s := data.Stacks[sp.slot]
+			if s == "none" {
+				continue
+			}
+			var paths []string
+			for _, p := range sp.paths {
+				if _, err := fsutil.Fs.Stat(filepath.Join(projectRoot, p)); err == nil {
+					paths = append(paths, p)
+				}
+			}
+			if len(paths) == 0 {
+				continue
+			}
+			d := data
+			d.Slot, d.Stack = sp.slot, s
+			msg, err := renderMessage(params.StackCommitMessage, d)
+			if err != nil {
+				return nil, err
+			}
+			commits = append(commits, git.Commit{Message: msg, Paths: paths})
+		}
+	}
+
+	msg, err := renderMessage(params.CommitMessage, data)
+	if err != nil {
+		return nil, err
+	}
+	return append(commits, git.Commit{Message: msg}), nil
+}
@@ -0,0 +1,109 @@
+package cli
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"os/signal"
+	"path/filepath"
+	"slices"
+	"sort"
+	"syscall"
+
+	"github.com/b-jonathan/taco/internal/devx"
+	"github.com/b-jonathan/taco/internal/manifest"
+	"github.com/b-jonathan/taco/internal/nodepkg"
+	"github.com/b-jonathan/taco/internal/stacks"
+	"github.com/spf13/cobra"
+)
+
+func devCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "dev [dir]",
+		Short: "Run all scaffolded services together",
+		Long: `Starts every service of a scaffolded project (frontend, backend, local database)
+with prefixed output. Crashed services are restarted with backoff and Ctrl-C stops all of them.`,
+		Args: cobra.MaximumNArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			projectRoot := "."
+			if len(args) > 0 && args[0] != "" {
+				projectRoot = args[0]
+			}
+
+			ctx, stop := signal.NotifyContext(cmd.Context(), os.Interrupt, syscall.SIGTERM)
+			defer stop()
+
+			services, err := devServices(ctx, projectRoot)
+			if err != nil {
+				return err
+			}
+
+			if only, _ := cmd.Flags().GetStringSlice("only"); len(only) > 0 {
+				services = slices.DeleteFunc(services, func(s stacks.Service) bool {
+					return !slices.Contains(only, s.Name)
+				})
+			}
+			if len(services) == 0 {
+				return fmt.Errorf("nothing to run in %s", projectRoot)
+			}
+
+			return devx.Run(ctx, services)
+		},
+	}
+	cmd.Flags().StringSlice("only", nil, "Only run the named services (e.g. frontend,backend)")
+	return cmd
+}
+
+// devServices collects services from the stacks recorded in the project
+// manifest, falling back to the default frontend/backend layout.
+func devServices(ctx context.Context, projectRoot string) ([]stacks.Service, error) {
+	m, err := manifest.Read(projectRoot)
+	if errors.Is(err, os.ErrNotExist) {
+		return layoutServices(projectRoot), nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	opts := newOptions(projectRoot, m.Name, m.Stacks)
+	slots := make([]string, 0, len(m.Stacks))
+	for slot := range m.Stacks {
+		slots = append(slots, slot)
+	}
+	sort.Strings(slots)
+
+	var services []stacks.Service
+	for _, slot := range slots {
+		s, err := GetFactory(m.Stacks[slot])
+		if err != nil {
+			return nil, err
+		}
+		runner, ok := s.(stacks.Runner)
+		if !ok {
+			continue
+		}
+		svcs, err := runner.Services(ctx, opts)
+		if err != nil {
+			return nil, fmt.Errorf("%s services: %w", s.Name(), err)
+		}
+		services = append(services, svcs...)
+	}
+	return services, nil
+}
+
+func layoutServices(projectRoot string) []stacks.Service {
+	var services []stacks.Service
+	for _, svc := range []stacks.Service{
+		{Name: "frontend", Port: 3000},
+		{Name: "backend", Port: 4000},
+	} {
+		svc.Dir = filepath.Join(projectRoot, svc.Name)
+		if !nodepkg.HasScript(svc.Dir, "dev") {
+			continue
+		}
+		svc.Cmd = "npm run dev"
+		services = append(services, svc)
+	}
+	return services
+}
@@ -0,0 +1,73 @@
+package cli
+
+import (
+	"context"
+	"fmt"
+	"slices"
+
+	"github.com/b-jonathan/taco/internal/envsync"
+	"github.com/b-jonathan/taco/internal/prompt"
+	"github.com/b-jonathan/taco/internal/remote"
+)
+
+// selectEnv collects the env entries to push, applying --env-allow/--env-deny
+// and, when interactive, letting the user untick keys.
+func selectEnv(projectRoot string, params InitParams) ([]envsync.Entry, error) {
+	entries, err := envsync.Collect(projectRoot, envsync.Files)
+	if err != nil {
+		return nil, err
+	}
+	entries, err = envsync.Filter(entries, params.EnvAllow, params.EnvDeny)
+	if err != nil {
+		return nil, err
+	}
+	if len(entries) == 0 || params.EnvDryRun || !prompt.IsTTY() {
+		return entries, nil
+	}
+
+	keys := make([]string, len(entries))
+	for i, e := range entries {
+		keys[i] = e.Key
+	}
+	picked, err := prompt.CreateSurveyMultiSelect("Select env keys to upload:", keys, prompt.AskOpts{
+		Default:  keys,
+		PageSize: 15,
+	})
+	if err != nil {
+		return nil, err
+	}
+	return slices.DeleteFunc(entries, func(e envsync.Entry) bool {
+		return !slices.Contains(picked, e.Key)
+	}), nil
+}
+
+// pushEnv uploads entries as secrets or variables. With repo == nil (dry run)
+// it only lists what would be uploaded.
+func pushEnv(ctx context.Context, provider remote.Provider, repo *remote.Repo, entries []envsync.Entry, asVariables bool) error {
+	kind := "secret"
+	if asVariables {
+		kind = "variable"
+	}
+	if len(entries) == 0 {
+		fmt.Println("No env keys to upload.")
+		return nil
+	}
+
+	for _, e := range entries {
+		if repo == nil {
+			fmt.Printf("  %s (%s) -> %s %s\n", e.Key, e.Source, kind, envsync.Mask(e.Value))
+			continue
+		}
+		var err error
+		if asVariables {
+			err = provider.SetVariable(ctx, repo, e.Key, e.Value)
+		} else {
+			err = provider.SetSecret(ctx, repo, e.Key, e.Value)
+		}
+		if err != nil {
+			return err
+		}
+		fmt.Printf("  uploaded %s %s (%s)\n", kind, e.Key, e.Source)
+	}
+	return nil
+}
@@ -0,0 +1,68 @@
+package cli
+
+import (
+	"context"
+	"fmt"
+	"slices"
+
+	"github.com/b-jonathan/taco/internal/git"
+	"github.com/b-jonathan/taco/internal/remote"
+)
+
+// pushTarget is where init pushes the scaffold.
+type pushTarget struct {
+	repo      *remote.Repo // nil for a bare --remote-url
+	remoteURL string
+	branch    string // branch the scaffold is pushed to
+	base      string // pull request base; empty when pushing directly
+	existing  bool   // never delete a repository taco did not create
+}
+
+// resolveExisting looks up the --repo/--remote-url target and checks it can take
+// the scaffold: the branch must not exist yet, and for --pr the base must.
+func resolveExisting(ctx context.Context, provider remote.Provider, params InitParams) (*pushTarget, error) {
+	t := &pushTarget{remoteURL: params.RemoteURL, branch: params.DefaultBranch, existing: true}
+	if params.Repo != "" {
+		repo, err := provider.GetRepo(ctx, params.Repo)
+		if err != nil {
+			return nil, err
+		}
+		t.repo = repo
+		if t.remoteURL == "" {
+			t.remoteURL = repo.RemoteURL(params.Remote)
+		}
+	}
+
+	branches, err := git.RemoteBranches(ctx, t.remoteURL)
+	if err != nil {
+		return nil, err
+	}
+
+	if params.PR {
+		t.base, t.branch = t.repo.DefaultBranch, params.PRBranch
+		if t.base == "" || !slices.Contains(branches, t.base) {
+			return nil, fmt.Errorf("%s has no default branch to open a pull request against; push without --pr", params.Repo)
+		}
+	}
+	if slices.Contains(branches, t.branch) {
+		return nil, fmt.Errorf("%s already has a %q branch; pick another with --default-branch or --pr-branch", t.remoteURL, t.branch)
+	}
+	return t, nil
+}
+
+// protectedBranch is the branch the repository policy applies to.
+func (t *pushTarget) protectedBranch() string {
+	if t.base != "" {
+		return t.base
+	}
+	return t.branch
+}
+
+// push commits and pushes the scaffold, on top of the base branch for pull requests.
+func (t *pushTarget) push(ctx context.Context, projectRoot string, commits []git.Commit, opts git.Options) error {
+	opts.Branch = t.branch
+	if t.base != "" {
+		return git.InitAndPushOnto(ctx, projectRoot, t.remoteURL, t.base, commits, opts)
+	}
+	return git.InitAndPush(ctx, projectRoot, t.remoteURL, commits, opts)
+}
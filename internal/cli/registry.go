@@ -4,20 +4,50 @@ import (
 	"fmt"
 
 	"github.com/b-jonathan/taco/internal/stacks"
+	"github.com/b-jonathan/taco/internal/stacks/astro"
+	"github.com/b-jonathan/taco/internal/stacks/docker"
 	"github.com/b-jonathan/taco/internal/stacks/express"
+	"github.com/b-jonathan/taco/internal/stacks/fastapi"
+	"github.com/b-jonathan/taco/internal/stacks/fastify"
 	"github.com/b-jonathan/taco/internal/stacks/firebase"
+	"github.com/b-jonathan/taco/internal/stacks/githubactions"
+	"github.com/b-jonathan/taco/internal/stacks/golang"
+	"github.com/b-jonathan/taco/internal/stacks/hono"
 	"github.com/b-jonathan/taco/internal/stacks/mongodb"
+	"github.com/b-jonathan/taco/internal/stacks/mysql"
+	"github.com/b-jonathan/taco/internal/stacks/nestjs"
 	"github.com/b-jonathan/taco/internal/stacks/nextjs"
+	"github.com/b-jonathan/taco/internal/stacks/nuxt"
+	"github.com/b-jonathan/taco/internal/stacks/postgres"
+	"github.com/b-jonathan/taco/internal/stacks/redis"
+	"github.com/b-jonathan/taco/internal/stacks/sqlite"
+	"github.com/b-jonathan/taco/internal/stacks/sveltekit"
+	"github.com/b-jonathan/taco/internal/stacks/vitereact"
 )
 
 type Stack = stacks.Stack
 
 var Registry = map[string]Stack{
-	"express":  express.New(),
-	"nextjs":   nextjs.New(),
-	"mongodb":  mongodb.New(),
-	"firebase": firebase.New(), // TODO: implement Firebase stack
-	"none":     nil,
+	"express":        express.New(),
+	"fastify":        fastify.New(),
+	"nestjs":         nestjs.New(),
+	"hono":           hono.New(),
+	"go":             golang.New(),
+	"fastapi":        fastapi.New(),
+	"nextjs":         nextjs.New(),
+	"vite-react":     vitereact.New(),
+	"sveltekit":      sveltekit.New(),
+	"nuxt":           nuxt.New(),
+	"astro":          astro.New(),
+	"mongodb":        mongodb.New(),
+	"mysql":          mysql.New(),
+	"postgres":       postgres.New(),
+	"sqlite":         sqlite.New(),
+	"redis":          redis.New(),
+	"firebase":       firebase.New(), // TODO: implement Firebase stack
+	"docker":         docker.New(),
+	"github-actions": githubactions.New(),
+	"none":           nil,
 }
 
 func GetFactory(key string) (stacks.Stack, error) {
@@ -3,17 +3,24 @@ package cli
 import (
 	"context"
 	"fmt"
+	"maps"
+	"path/filepath"
+	"slices"
 	"strconv"
 	"strings"
 	"time"
 
 	"github.com/AlecAivazis/survey/v2"
 	"github.com/b-jonathan/taco/internal/fsutil"
-	"github.com/b-jonathan/taco/internal/gh"
 	"github.com/b-jonathan/taco/internal/git"
+	"github.com/b-jonathan/taco/internal/hooks"
 	"github.com/b-jonathan/taco/internal/logx"
+	"github.com/b-jonathan/taco/internal/manifest"
+	"github.com/b-jonathan/taco/internal/policy"
 	"github.com/b-jonathan/taco/internal/prompt"
+	"github.com/b-jonathan/taco/internal/remote"
 	"github.com/b-jonathan/taco/internal/stacks"
+	"github.com/b-jonathan/taco/internal/stacks/githubactions"
 	"github.com/joho/godotenv"
 	"github.com/spf13/cobra"
 	"golang.org/x/sync/errgroup"
@@ -36,27 +43,8 @@ func newRootCmd() *cobra.Command {
 		},
 	}
 
-	cmd.PersistentPreRunE = func(cmd *cobra.Command, args []string) error {
-		// ctx := cmd.Context()
-		// if gh.HasClient(ctx) {
-		// 	return nil
-		// }
-		// token := os.Getenv("GITHUB_TOKEN")
-
-		// if token == "" {
-
-		// 	return fmt.Errorf("set GITHUB_TOKEN")
-		// }
-		// client := gh.NewClient(ctx, token)
-		// client.UserAgent = "taco-cli"
-		// client, err := gh.EnsureClient(ctx)
-		// if err != nil {
-		// 	return err
-		// }
-		// cmd.SetContext(gh.WithContext(ctx, client))
-		return nil
-	}
 	cmd.AddCommand(initCmd())
+	cmd.AddCommand(devCmd())
 	return cmd
 }
 
@@ -76,16 +64,35 @@ func gatherInitParams(cmd *cobra.Command, args []string) (InitParams, error) {
 		params.Name = name
 	}
 
-	if f := cmd.Flags().Lookup("github"); f != nil && f.Changed {
+	host, _ := cmd.Flags().GetString("host")
+	params.Host = strings.ToLower(strings.TrimSpace(host))
+	params.BaseURL, _ = cmd.Flags().GetString("base-url")
+	params.Token, _ = cmd.Flags().GetString("token")
+	hostLabel := map[string]string{"github": "GitHub", "gitlab": "GitLab", "gitea": "Gitea"}[params.Host]
+	if hostLabel == "" {
+		return params, fmt.Errorf("unknown host %q. available: %v", params.Host, remote.Hosts)
+	}
+
+	params.Repo, _ = cmd.Flags().GetString("repo")
+	params.RemoteURL, _ = cmd.Flags().GetString("remote-url")
+	params.PR, _ = cmd.Flags().GetBool("pr")
+	params.PRBranch, _ = cmd.Flags().GetString("pr-branch")
+	if params.PR && params.Repo == "" {
+		return params, fmt.Errorf("--pr needs --repo to open the pull request")
+	}
+
+	if params.ExistingRepo() {
+		params.UseGitHub = true
+	} else if f := cmd.Flags().Lookup("github"); f != nil && f.Changed {
 		b, _ := strconv.ParseBool(f.Value.String())
 		params.UseGitHub = b
 	} else {
 		if prompt.IsTTY() {
 			useGH, err := prompt.CreateSurveyConfirm(
-				"Create GitHub repository and push initial commit?",
+				fmt.Sprintf("Create %s repository and push initial commit?", hostLabel),
 				prompt.AskOpts{
 					Default: true,
-					Help:    "If yes, taco will create a repo on your GitHub account and push the scaffolded code.",
+					Help:    fmt.Sprintf("If yes, taco will create a repo on your %s account and push the scaffolded code.", hostLabel),
 				},
 			)
 			if err != nil {
@@ -95,11 +102,87 @@ func gatherInitParams(cmd *cobra.Command, args []string) (InitParams, error) {
 		}
 	}
 
+	// CI comes with GitHub unless asked for explicitly
+	if f := cmd.Flags().Lookup("ci"); f != nil && f.Changed {
+		b, _ := strconv.ParseBool(f.Value.String())
+		params.CI = b
+	} else {
+		params.CI = params.UseGitHub && params.Host == "github"
+	}
+
+	params.PushEnv, _ = cmd.Flags().GetBool("push-env")
+	params.EnvAs, _ = cmd.Flags().GetString("env-as")
+	params.EnvAllow, _ = cmd.Flags().GetStringSlice("env-allow")
+	params.EnvDeny, _ = cmd.Flags().GetStringSlice("env-deny")
+	params.EnvDryRun, _ = cmd.Flags().GetBool("env-dry-run")
+	if params.EnvAs != "secrets" && params.EnvAs != "variables" {
+		return params, fmt.Errorf("--env-as must be secrets or variables, got %q", params.EnvAs)
+	}
+
+	params.Git, _ = cmd.Flags().GetBool("git")
+	params.GitExplicit = params.Git && cmd.Flags().Changed("git")
+	params.Hooks, _ = cmd.Flags().GetString("hooks")
+	if err := hooks.Validate(params.Hooks); err != nil {
+		return params, err
+	}
+	// pushing needs a repository
 	if params.UseGitHub {
+		params.Git = true
+	}
+	params.DefaultBranch, _ = cmd.Flags().GetString("default-branch")
+	params.Sign, _ = cmd.Flags().GetString("sign")
+	params.SigningKey, _ = cmd.Flags().GetString("signing-key")
+	params.CommitMessage, _ = cmd.Flags().GetString("commit-message")
+	params.StackCommitMessage, _ = cmd.Flags().GetString("stack-commit-message")
+	params.CommitPerStack, _ = cmd.Flags().GetBool("commit-per-stack")
+	for flag, id := range map[string]*git.Identity{"git-author": &params.GitAuthor, "git-committer": &params.GitCommitter} {
+		if v, _ := cmd.Flags().GetString(flag); v != "" {
+			parsed, err := git.ParseIdentity(v)
+			if err != nil {
+				return params, fmt.Errorf("--%s: %w", flag, err)
+			}
+			*id = parsed
+		}
+	}
+	if err := gitOptions(params).Validate(); err != nil {
+		return params, err
+	}
+	// catch template typos before anything is scaffolded
+	for _, tmpl := range []string{params.CommitMessage, params.StackCommitMessage} {
+		if _, err := parseMessage(tmpl); err != nil {
+			return params, err
+		}
+	}
+
+	if params.UseGitHub {
+		params.Protect, _ = cmd.Flags().GetBool("protect")
+		params.PolicyFile, _ = cmd.Flags().GetString("policy")
+		if params.PolicyFile != "" {
+			params.Protect = true
+		}
+	}
+
+	// creation settings make no sense for a repository that already exists
+	if params.ExistingRepo() {
+		for _, name := range []string{"org", "team", "team-permission", "visibility", "topic", "homepage", "private", "description"} {
+			if cmd.Flags().Changed(name) {
+				return params, fmt.Errorf("--%s only applies when creating a repository, not with --repo or --remote-url", name)
+			}
+		}
+	}
+
+	if params.UseGitHub && !params.ExistingRepo() {
+		params.Org, _ = cmd.Flags().GetString("org")
+		params.Team, _ = cmd.Flags().GetString("team")
+		params.TeamPermission, _ = cmd.Flags().GetString("team-permission")
+		params.Visibility, _ = cmd.Flags().GetString("visibility")
+		params.Topics, _ = cmd.Flags().GetStringSlice("topic")
+		params.Homepage, _ = cmd.Flags().GetString("homepage")
+
 		if f := cmd.Flags().Lookup("private"); f != nil && f.Changed {
 			b, _ := strconv.ParseBool(f.Value.String())
 			params.Private = b
-		} else {
+		} else if params.Visibility == "" {
 			b, err := prompt.CreateSurveyConfirm("Make repository private?", prompt.AskOpts{
 				Default: false,
 			})
@@ -111,34 +194,36 @@ func gatherInitParams(cmd *cobra.Command, args []string) (InitParams, error) {
 			}
 		}
 
-		if v, _ := cmd.Flags().GetString("remote"); v != "" {
-			params.Remote = v
+		if v, _ := cmd.Flags().GetString("description"); v != "" {
+			params.Description = v
 		} else {
+			// optional field; allow empty in non-TTY
 			if prompt.IsTTY() {
-				r, err := prompt.CreateSurveySelect("Remote URL type", []string{"ssh", "https"}, prompt.AskOpts{
-					Default:  "ssh",
-					PageSize: 2,
+				desc, err := prompt.CreateSurveyInput("Repository description", prompt.AskOpts{
+					Default: "",
+					Help:    "you can leave this empty",
 				})
 				if err != nil {
 					return params, err
 				}
-				params.Remote = r
+				params.Description = desc
 			}
 		}
+	}
 
-		if v, _ := cmd.Flags().GetString("description"); v != "" {
-			params.Description = v
+	if params.UseGitHub && params.RemoteURL == "" {
+		if v, _ := cmd.Flags().GetString("remote"); v != "" {
+			params.Remote = v
 		} else {
-			// optional field; allow empty in non-TTY
 			if prompt.IsTTY() {
-				desc, err := prompt.CreateSurveyInput("Repository description", prompt.AskOpts{
-					Default: "",
-					Help:    "you can leave this empty",
+				r, err := prompt.CreateSurveySelect("Remote URL type", []string{"ssh", "https"}, prompt.AskOpts{
+					Default:  "ssh",
+					PageSize: 2,
 				})
 				if err != nil {
 					return params, err
 				}
-				params.Description = desc
+				params.Remote = r
 			}
 		}
 	}
@@ -146,6 +231,24 @@ func gatherInitParams(cmd *cobra.Command, args []string) (InitParams, error) {
 	return params, nil
 }
 
+// slot is one stack choice asked for by init.
+type slot struct {
+	key     string // key in the stack map, the manifest and commit message templates
+	prompt  string
+	choices []string // labels; lowercased they are registry keys
+}
+
+// slots are the choices init asks for, in order. A new slot type also needs
+// its steps placed in initCmd and, if it owns a folder, an entry in slotPaths.
+var slots = []slot{
+	{"frontend", "Choose a Frontend Stack:\n", []string{"NextJS", "Vite-React", "SvelteKit", "Nuxt", "Astro", "None"}},
+	{"backend", "Choose a Backend Stack:\n", []string{"Express", "Fastify", "NestJS", "Hono", "Go", "FastAPI", "None"}},
+	{"database", "Choose a Database Stack:\n", []string{"MongoDB", "Postgres", "MySQL", "SQLite", "None"}},
+	{"cache", "Choose a Cache Stack:\n", []string{"Redis", "None"}},
+	{"auth", "Choose an Auth Stack:\n", []string{"Firebase", "None"}},
+	{"infra", "Choose an Infrastructure Stack:\n", []string{"Docker", "None"}},
+}
+
 func initCmd() *cobra.Command {
 	cmd := &cobra.Command{
 		Use:   "init [name]",
@@ -160,50 +263,84 @@ func initCmd() *cobra.Command {
 				return err
 			}
 
+			// resolve the provider up front so a missing token or a bad option
+			// combination fails before scaffolding
+			var provider remote.Provider
+			repoOpts := remote.CreateRepoOptions{
+				Name:        params.Name,
+				Private:     params.Private,
+				Description: params.Description,
+
+				Org:            params.Org,
+				Visibility:     params.Visibility,
+				Team:           params.Team,
+				TeamPermission: params.TeamPermission,
+				Topics:         params.Topics,
+				Homepage:       params.Homepage,
+				DefaultBranch:  params.DefaultBranch,
+			}
+			var target *pushTarget
+			if params.UseGitHub {
+				if err := repoOpts.Validate(); err != nil {
+					return err
+				}
+				// a bare --remote-url is pushed with git alone and needs no API token
+				if params.Repo != "" || params.RemoteURL == "" {
+					if provider, err = remote.New(rootCtx, params.Host, params.BaseURL, params.Token, !params.ExistingRepo()); err != nil {
+						return err
+					}
+				}
+				if top, ok := git.Enclosing(rootCtx, params.Name); ok {
+					return fmt.Errorf("%s would be created inside the git repository at %s; push from there or run init elsewhere", params.Name, top)
+				}
+				if params.ExistingRepo() {
+					if target, err = resolveExisting(rootCtx, provider, params); err != nil {
+						return err
+					}
+				}
+				if params.PolicyFile != "" {
+					if _, err := policy.Load(params.PolicyFile, policy.Default(params.DefaultBranch, nil, nil)); err != nil {
+						return err
+					}
+				}
+			}
+
+			if params.Git {
+				if err := git.CheckIdentity(rootCtx, ".", gitOptions(params)); err != nil {
+					return fmt.Errorf("%w (or pass --git=false)", err)
+				}
+			}
+
 			projectRoot := params.Name
 			if err := fsutil.Fs.MkdirAll(projectRoot, 0o755); err != nil {
 				return fmt.Errorf("mkdir project root: %w", err)
 			}
 
-			//TODO: We're gonna have to refactor this into a "dependency-style" selection, so only db's supported by chosen backend are seen
-
-			stack["frontend"], _ = prompt.CreateSurveySelect("Choose a Frontend Stack:\n", []string{"NextJS", "None"}, prompt.AskOpts{})
-			stack["frontend"] = strings.ToLower(stack["frontend"])
-			frontend, err := GetFactory(stack["frontend"])
-			if err != nil {
-				return err
+			selected := map[string]stacks.Stack{}
+			for _, sl := range slots {
+				choice, _ := prompt.CreateSurveySelect(sl.prompt, sl.choices, prompt.AskOpts{})
+				stack[sl.key] = strings.ToLower(choice)
+				if selected[sl.key], err = GetFactory(stack[sl.key]); err != nil {
+					return err
+				}
 			}
+			frontend, backend, database, cache, auth, infra := selected["frontend"], selected["backend"], selected["database"], selected["cache"], selected["auth"], selected["infra"]
 
-			stack["backend"], _ = prompt.CreateSurveySelect("Choose a Backend Stack:\n", []string{"Express", "None"}, prompt.AskOpts{})
-			stack["backend"] = strings.ToLower(stack["backend"])
-			backend, err := GetFactory(stack["backend"])
-			if err != nil {
-				return err
-			}
-			stack["database"], _ = prompt.CreateSurveySelect("Choose a Database Stack:\n", []string{"MongoDB", "None"}, prompt.AskOpts{})
-			stack["database"] = strings.ToLower(stack["database"])
-			database, err := GetFactory(stack["database"])
-			if err != nil {
-				return err
+			var ci stacks.Stack
+			if params.CI {
+				stack["ci"] = "github-actions"
+				if ci, err = GetFactory(stack["ci"]); err != nil {
+					return err
+				}
 			}
 
-			stack["auth"], _ = prompt.CreateSurveySelect("Choose an Auth Stack:\n", []string{"Firebase", "None"}, prompt.AskOpts{})
-			stack["auth"] = strings.ToLower(stack["auth"])
-			auth, err := GetFactory(stack["auth"])
-			if err != nil {
+			all := []stacks.Stack{frontend, backend, database, cache, auth, infra, ci}
+			if err := stacks.CheckCompatible(all...); err != nil {
 				return err
 			}
 
-			opts := &stacks.Options{
-				ProjectRoot: projectRoot,
-				AppName:     params.Name,
-				Frontend:    stack["frontend"],
-				Backend:     stack["backend"],
-				Database:    stack["database"],
-				FrontendURL: "http://localhost:3000",
-				BackendURL:  "http://localhost:4000",
-				Port:        4000,
-			}
+			opts := newOptions(projectRoot, params.Name, stack)
+			opts.DefaultBranch = params.DefaultBranch
 
 			// Rollback logic
 			rollbackNeeded := true
@@ -215,7 +352,7 @@ func initCmd() *cobra.Command {
 				// use a fresh context for rollback so it isn't canceled
 				rbCtx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
 				defer cancel()
-				rollbackStacks(rbCtx, opts, frontend, backend, database, auth)
+				rollbackStacks(rbCtx, opts, all...)
 			}()
 
 			// This is core core
@@ -224,8 +361,10 @@ func initCmd() *cobra.Command {
 
 			g.Go(func() error { return runSelected(ctx, "Frontend", frontend, opts, []string{"init", "generate"}) })
 			g.Go(func() error { return runSelected(ctx, "Backend", backend, opts, []string{"init", "generate"}) })
-			g.Go(func() error { return runSelected(ctx, "Database", database, opts, []string{"init", "seed"}) })
+			g.Go(func() error { return runSelected(ctx, "Database", database, opts, []string{"init"}) })
+			g.Go(func() error { return runSelected(ctx, "Cache", cache, opts, []string{"init"}) })
 			g.Go(func() error { return runSelected(ctx, "Auth", auth, opts, []string{"init"}) })
+			g.Go(func() error { return runSelected(ctx, "Infra", infra, opts, []string{"init"}) })
 
 			if err := g.Wait(); err != nil {
 				return err
@@ -235,7 +374,12 @@ func initCmd() *cobra.Command {
 				return err
 			}
 
-			if err := runSelected(rootCtx, "Database", database, opts, []string{"generate"}); err != nil {
+			// seeding runs last so seeders can use the schema Generate wrote
+			if err := runSelected(rootCtx, "Database", database, opts, []string{"generate", "seed"}); err != nil {
+				return err
+			}
+			// after the database: both insert at anchors in the backend entry file
+			if err := runSelected(rootCtx, "Cache", cache, opts, []string{"generate"}); err != nil {
 				return err
 			}
 
@@ -256,6 +400,9 @@ func initCmd() *cobra.Command {
 				if err := runSelected(ctx, "Database", database, opts, []string{"post"}); err != nil {
 					return err
 				}
+				if err := runSelected(ctx, "Cache", cache, opts, []string{"post"}); err != nil {
+					return err
+				}
 				return nil
 			})
 
@@ -263,35 +410,121 @@ func initCmd() *cobra.Command {
 				return err
 			}
 
+			// infra wraps the other stacks, so it runs once they are done
+			if err := runSelected(rootCtx, "Infra", infra, opts, []string{"generate", "post"}); err != nil {
+				return err
+			}
+			if err := runSelected(rootCtx, "CI", ci, opts, []string{"init", "generate", "post"}); err != nil {
+				return err
+			}
+
+			if err := writeManifest(projectRoot, params.Name, stack); err != nil {
+				return err
+			}
+			if err := writeGitignore(projectRoot, opts, all...); err != nil {
+				return err
+			}
+
+			if params.Git {
+				initLocalRepo(cmd.Context(), projectRoot, params, stack)
+			}
+
 			// This is additional templates
 			if params.UseGitHub {
-				fmt.Println("Creating GitHub repository...")
-
-				repo, err := gh.CreateRepo(cmd.Context(), gh.CreateRepoOptions{
-					Name:        params.Name,
-					Private:     params.Private,
-					Description: params.Description,
-				})
-				if err != nil {
-					return err
+				if target == nil {
+					fmt.Printf("Creating %s repository...\n", provider.Name())
+
+					repo, err := provider.CreateRepo(cmd.Context(), repoOpts)
+					if err != nil {
+						return err
+					}
+					fmt.Println("Created:", repo.HTMLURL)
+					target = &pushTarget{repo: repo, remoteURL: repo.RemoteURL(params.Remote), branch: params.DefaultBranch}
 				}
+				repo := target.repo
 
-				fmt.Println("Created:", repo.GetHTMLURL())
+				// only repositories created above are deleted on failure
+				cleanup := func() {
+					if !target.existing {
+						_ = provider.DeleteRepo(cmd.Context(), repo)
+					}
+				}
 
-				remoteURL := repo.GetSSHURL()
-				if params.Remote == "https" {
-					remoteURL = repo.GetCloneURL()
+				var pol policy.Policy
+				if params.Protect && repo == nil {
+					logx.Warnf("--protect needs --repo to know the repository; skipping the policy")
+					params.Protect = false
+				}
+				if params.Protect {
+					if pol, err = repoPolicy(opts, params, repo, target.protectedBranch(), ci != nil); err != nil {
+						cleanup()
+						return err
+					}
+					// written before the initial commit so the files ship with it
+					if err := policy.WriteFiles(projectRoot, pol); err != nil {
+						cleanup()
+						return err
+					}
 				}
 
 				fmt.Println("Committing and pushing...")
 
-				if err := git.InitAndPush(cmd.Context(), projectRoot, remoteURL, "initial-commit"); err != nil {
-					// cleanup
-					_ = gh.DeleteRepo(cmd.Context(), repo)
+				commits, err := scaffoldCommits(projectRoot, params, stack)
+				if err != nil {
+					cleanup()
+					return err
+				}
+				if err := target.push(cmd.Context(), projectRoot, commits, gitOptions(params)); err != nil {
+					cleanup()
 					return fmt.Errorf("git push failed: %w", err)
 				}
 
-				fmt.Println("Pushed:", repo.GetHTMLURL())
+				fmt.Printf("Pushed %s to %s\n", target.branch, target.remoteURL)
+
+				// the scaffold is pushed at this point, so follow-up problems
+				// are reported but never roll the project back
+				if target.base != "" {
+					url, err := provider.OpenPullRequest(cmd.Context(), repo, remote.PullRequest{
+						Head:  target.branch,
+						Base:  target.base,
+						Title: "Scaffold " + params.Name + " with taco",
+						Body:  "Stacks: " + stackSummary(stack),
+					})
+					if err != nil {
+						logx.Warnf("open pull request from %s: %v", target.branch, err)
+					} else {
+						fmt.Println("Opened pull request:", url)
+					}
+				}
+
+				if params.Protect {
+					if applier, ok := provider.(remote.PolicyApplier); !ok {
+						logx.Warnf("%s does not support repository policies; only the .github files were generated", provider.Name())
+					} else if err := applier.ApplyPolicy(cmd.Context(), repo, pol); err != nil {
+						logx.Warnf("apply repository policy: %v", err)
+					} else {
+						fmt.Printf("Protected %s (checks: %s)\n", pol.Branch, strings.Join(pol.RequiredChecks, ", "))
+					}
+				}
+
+				if params.PushEnv && !params.EnvDryRun {
+					if repo == nil {
+						logx.Warnf("--push-env needs --repo to know the repository; skipping")
+					} else if entries, err := selectEnv(projectRoot, params); err != nil {
+						logx.Warnf("select env keys: %v", err)
+					} else if err := pushEnv(cmd.Context(), provider, repo, entries, params.EnvAs == "variables"); err != nil {
+						logx.Warnf("upload env %s: %v", params.EnvAs, err)
+					}
+				}
+			}
+
+			if params.EnvDryRun {
+				fmt.Printf("Env keys that --push-env would upload as %s:\n", params.EnvAs)
+				if entries, err := selectEnv(projectRoot, params); err != nil {
+					logx.Warnf("select env keys: %v", err)
+				} else {
+					_ = pushEnv(cmd.Context(), provider, nil, entries, params.EnvAs == "variables")
+				}
 			}
 
 			rollbackNeeded = false
@@ -303,10 +536,105 @@ func initCmd() *cobra.Command {
 	cmd.Flags().Bool("private", false, "Make the repository private")
 	cmd.Flags().String("remote", "", "Remote URL type ssh or https")
 	cmd.Flags().String("description", "", "Repository description")
-	cmd.Flags().Bool("github", false, "Create and push to a GitHub repository")
+	cmd.Flags().Bool("github", false, "Create and push to a remote repository (on --host)")
+	cmd.Flags().String("host", "github", "Remote host: github, gitlab or gitea")
+	cmd.Flags().String("org", "", "Create the repository under this organization (GitLab: group, Gitea: org)")
+	cmd.Flags().String("team", "", "Team (slug) to grant access to the repository; requires --org")
+	cmd.Flags().String("team-permission", "push", "Team permission: pull, triage, push, maintain or admin")
+	cmd.Flags().String("visibility", "", "Repository visibility: public, private or internal (overrides --private)")
+	cmd.Flags().StringSlice("topic", nil, "Repository topic (repeatable)")
+	cmd.Flags().String("homepage", "", "Repository homepage URL")
+	cmd.Flags().String("default-branch", "main", "Default branch to push the scaffold to")
+	cmd.Flags().String("token", "", "Access token for --host (default: GITHUB_TOKEN/GH_TOKEN, git credential helper, then gh CLI; GITLAB_TOKEN or GITEA_TOKEN)")
+	cmd.Flags().String("base-url", "", "Base URL of a self-hosted instance (GitHub Enterprise, GitLab, Gitea)")
+	cmd.Flags().Bool("push-env", false, "Upload backend/frontend env values as Actions secrets or variables after push")
+	cmd.Flags().String("env-as", "secrets", "Upload env values as secrets or variables")
+	cmd.Flags().StringSlice("env-allow", nil, "Only upload env keys matching these globs (e.g. NEXT_PUBLIC_FIREBASE_*)")
+	cmd.Flags().StringSlice("env-deny", nil, "Never upload env keys matching these globs (e.g. PORT)")
+	cmd.Flags().Bool("env-dry-run", false, "List the env keys that would be uploaded without uploading")
+	cmd.Flags().Bool("git", true, "Initialize a git repository and commit the scaffold (always on with --github)")
+	cmd.Flags().String("hooks", "none", "Pre-commit hooks running each lint-check: none, git, husky or lefthook")
+	cmd.Flags().String("git-author", "", "Author of the scaffold commits, \"Name <email>\" (default: git config)")
+	cmd.Flags().String("git-committer", "", "Committer of the scaffold commits, \"Name <email>\" (default: --git-author)")
+	cmd.Flags().String("sign", "", "Sign the scaffold commits with gpg or ssh")
+	cmd.Flags().String("signing-key", "", "GPG key id or SSH public key path for --sign (default: user.signingkey)")
+	cmd.Flags().String("commit-message", defaultCommitMessage, "Commit message template (fields: .Name, .Stacks)")
+	cmd.Flags().Bool("commit-per-stack", false, "Make one commit per stack folder before the final commit")
+	cmd.Flags().String("stack-commit-message", defaultStackCommitMessage, "Per-stack commit message template (fields: .Name, .Stacks, .Slot, .Stack)")
+	cmd.Flags().String("repo", "", "Push to this existing, empty repository (owner/name) instead of creating one")
+	cmd.Flags().String("remote-url", "", "Push to this existing remote with git only (no API calls)")
+	cmd.Flags().Bool("pr", false, "With --repo: push to --pr-branch on top of the default branch and open a pull request")
+	cmd.Flags().String("pr-branch", "taco/scaffold", "Branch used for --pr")
+	cmd.Flags().Bool("protect", false, "Protect the default branch and apply merge settings, labels and .github files after push")
+	cmd.Flags().String("policy", "", "JSON file overriding the default repository policy (implies --protect)")
+	cmd.Flags().Bool("ci", false, "Generate a GitHub Actions CI workflow (default: on with --github)")
 	return cmd
 }
 
+func newOptions(projectRoot, name string, stack map[string]string) *stacks.Options {
+	return &stacks.Options{
+		ProjectRoot: projectRoot,
+		AppName:     name,
+		Frontend:    stack["frontend"],
+		Backend:     stack["backend"],
+		Database:    stack["database"],
+		Cache:       stack["cache"],
+		FrontendURL: "http://localhost:3000",
+		BackendURL:  "http://localhost:4000",
+		Port:        4000,
+	}
+}
+
+// writeManifest records the selected stacks so `taco dev` can find them later.
+func writeManifest(projectRoot, name string, stack map[string]string) error {
+	selected := map[string]string{}
+	for slot, s := range stack {
+		if s != "" && s != "none" {
+			selected[slot] = s
+		}
+	}
+	if err := manifest.Write(projectRoot, manifest.Manifest{Name: name, Stacks: selected}); err != nil {
+		return fmt.Errorf("write manifest: %w", err)
+	}
+	return nil
+}
+
+// baseIgnore opens every root .gitignore, before the stack sections.
+var baseIgnore = []string{"# OS and editor files", ".DS_Store", "Thumbs.db", ".idea/", ".vscode/*", "!.vscode/extensions.json", "*.log"}
+
+// writeGitignore merges the base patterns and those of every selected stack
+// into the root .gitignore, one section per stack.
+func writeGitignore(projectRoot string, opts *stacks.Options, selected ...stacks.Stack) error {
+	lines := append([]string{}, baseIgnore...)
+	for _, s := range selected {
+		ig, ok := s.(stacks.Ignorer)
+		if !ok {
+			continue
+		}
+		lines = append(lines, "# "+s.Name())
+		lines = append(lines, ig.GitIgnore(opts)...)
+	}
+	path := filepath.Join(projectRoot, ".gitignore")
+	if err := fsutil.EnsureFile(path); err != nil {
+		return fmt.Errorf("ensure gitignore file: %w", err)
+	}
+	if err := fsutil.AppendUniqueLines(path, lines); err != nil {
+		return fmt.Errorf("write .gitignore: %w", err)
+	}
+	return nil
+}
+
+// stackSummary lists the selected stacks as "slot: name" pairs, sorted by slot.
+func stackSummary(stack map[string]string) string {
+	var parts []string
+	for _, slot := range slices.Sorted(maps.Keys(stack)) {
+		if s := stack[slot]; s != "" && s != "none" {
+			parts = append(parts, slot+": "+s)
+		}
+	}
+	return strings.Join(parts, ", ")
+}
+
 func stackSteps(
 	ctx context.Context,
 	label string,
@@ -376,3 +704,21 @@ func runSelected(ctx context.Context, label string, s stacks.Stack, opts *stacks
 	}
 	return runSteps(label, steps)
 }
+
+// repoPolicy builds the policy for a freshly created repo: the generated CI
+// jobs become required checks and the team (or the owner) owns the code.
+func repoPolicy(opts *stacks.Options, params InitParams, repo *remote.Repo, branch string, withCI bool) (policy.Policy, error) {
+	var checks []string
+	if withCI {
+		checks = githubactions.Checks(opts)
+	}
+	owner := "@" + repo.Owner
+	if params.Team != "" {
+		owner = "@" + params.Org + "/" + params.Team
+	}
+	p := policy.Default(branch, checks, []string{owner})
+	if params.PolicyFile == "" {
+		return p, nil
+	}
+	return policy.Load(params.PolicyFile, p)
+}
@@ -1,14 +1,57 @@
 package cli
 
+import "github.com/b-jonathan/taco/internal/git"
+
 type InitParams struct {
 	Name         string
 	Description  string
 	Remote       string
 	Private      bool
 	Database_URI string
-	UseGitHub    bool
+	UseGitHub    bool // push to a remote repository on Host, not only GitHub
+	Host         string
+	BaseURL      string
+	Token        string // explicit token for Host; overrides the credential chain
+	CI           bool
+
+	Org            string
+	Team           string
+	TeamPermission string
+	Visibility     string
+	Topics         []string
+	Homepage       string
+	DefaultBranch  string
+
+	PushEnv   bool // upload env file values to the remote after push
+	EnvAs     string
+	EnvAllow  []string
+	EnvDeny   []string
+	EnvDryRun bool
+
+	Protect    bool   // apply the repository policy after push
+	PolicyFile string // JSON overrides for the default policy; implies Protect
+
+	Repo      string // existing "owner/name" to push to instead of creating one
+	RemoteURL string // existing remote to push to with plain git
+	PR        bool   // push to PRBranch and open a pull request instead
+	PRBranch  string
+
+	GitAuthor          git.Identity // overrides git config for the scaffold commits
+	GitCommitter       git.Identity
+	Sign               string // "", "gpg" or "ssh"
+	SigningKey         string
+	CommitMessage      string // text/template, see commitData
+	StackCommitMessage string
+	CommitPerStack     bool
+
+	Git         bool   // init a local repository and commit, even without a remote
+	GitExplicit bool   // --git was passed, which allows committing into an enclosing repository
+	Hooks       string // pre-commit hook manager, see hooks.Kinds
 }
 
+// ExistingRepo reports whether init pushes to a repository it did not create.
+func (p InitParams) ExistingRepo() bool { return p.Repo != "" || p.RemoteURL != "" }
+
 type Step struct {
 	Name string
 	Fn   func() error
@@ -0,0 +1,178 @@
+package devx
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net"
+	"os"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/b-jonathan/taco/internal/execx"
+	"github.com/b-jonathan/taco/internal/stacks"
+	"golang.org/x/sync/errgroup"
+)
+
+const (
+	minBackoff  = 500 * time.Millisecond
+	maxBackoff  = 30 * time.Second
+	stableAfter = 30 * time.Second // a run this long resets the backoff
+	maxRestarts = 5                // consecutive crashes before giving up
+	readyWithin = 90 * time.Second
+)
+
+var colors = []string{"\033[36m", "\033[35m", "\033[33m", "\033[32m", "\033[34m", "\033[31m"}
+
+// Run starts every service, restarts crashed ones with exponential backoff and
+// stops all of them once ctx is cancelled (e.g. Ctrl-C). It returns an error
+// only if a service keeps crashing or cannot start at all.
+func Run(ctx context.Context, services []stacks.Service) error {
+	if len(services) == 0 {
+		return fmt.Errorf("no services to run")
+	}
+
+	width := 0
+	for _, s := range services {
+		width = max(width, len(s.Name))
+	}
+
+	var mu sync.Mutex
+	useColor := colorEnabled()
+	writers := make([]*prefixWriter, len(services))
+	for i, s := range services {
+		prefix := fmt.Sprintf("%-*s | ", width, s.Name)
+		if useColor {
+			prefix = colors[i%len(colors)] + prefix + "\033[0m"
+		}
+		writers[i] = &prefixWriter{mu: &mu, out: os.Stdout, prefix: prefix}
+	}
+
+	// Check ports up front so we fail before anything is started.
+	run := make([]bool, len(services))
+	for i, s := range services {
+		if s.Port > 0 && PortInUse(s.Port) {
+			if !s.Reuse {
+				return fmt.Errorf("port %d for %s is already in use", s.Port, s.Name)
+			}
+			writers[i].Printf("port %d already listening, reusing the running instance", s.Port)
+			continue
+		}
+		run[i] = true
+	}
+
+	g, ctx := errgroup.WithContext(ctx)
+	for i, s := range services {
+		if !run[i] {
+			continue
+		}
+		w := writers[i]
+		g.Go(func() error { return supervise(ctx, s, w) })
+		if s.Port > 0 {
+			go waitReady(ctx, s, w)
+		}
+	}
+	return g.Wait()
+}
+
+// runCmd and after start a service and wait out a backoff; tests replace them.
+var (
+	runCmd = execx.RunCmdStream
+	after  = time.After
+)
+
+func supervise(ctx context.Context, s stacks.Service, w *prefixWriter) error {
+	var r restarts
+	for {
+		w.Printf("starting: %s", s.Cmd)
+		start := time.Now()
+		err := runCmd(ctx, s.Dir, s.Cmd, w, w)
+		w.Flush()
+		if ctx.Err() != nil {
+			w.Printf("stopped")
+			return nil
+		}
+
+		// a dev server exiting on its own is a crash too
+		if err == nil {
+			err = errors.New("exited with status 0")
+		}
+		backoff, ok := r.crashed(time.Since(start))
+		if !ok {
+			return fmt.Errorf("%s crashed %d times in a row, giving up: %w", s.Name, r.failures, err)
+		}
+		w.Printf("%v; restarting in %s", err, backoff)
+
+		select {
+		case <-ctx.Done():
+			return nil
+		case <-after(backoff):
+		}
+	}
+}
+
+// restarts counts a service's consecutive crashes.
+type restarts struct {
+	failures int
+}
+
+// crashed records a run that lasted ran and returns the delay before the next
+// start, doubling from minBackoff up to maxBackoff. A run longer than
+// stableAfter starts the count over. ok is false once the service has crashed
+// more than maxRestarts times in a row.
+func (r *restarts) crashed(ran time.Duration) (backoff time.Duration, ok bool) {
+	if ran > stableAfter {
+		r.failures = 0
+	}
+	r.failures++
+	if r.failures > maxRestarts {
+		return 0, false
+	}
+	backoff = minBackoff
+	for i := 1; i < r.failures && backoff < maxBackoff; i++ {
+		backoff *= 2
+	}
+	return min(backoff, maxBackoff), true
+}
+
+func waitReady(ctx context.Context, s stacks.Service, w *prefixWriter) {
+	ticker := time.NewTicker(500 * time.Millisecond)
+	defer ticker.Stop()
+	deadline := time.After(readyWithin)
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-deadline:
+			w.Printf("still not listening on port %d after %s", s.Port, readyWithin)
+			return
+		case <-ticker.C:
+			if PortInUse(s.Port) {
+				w.Printf("ready on port %d", s.Port)
+				return
+			}
+		}
+	}
+}
+
+// PortInUse reports whether something is accepting TCP connections on localhost:port.
+func PortInUse(port int) bool {
+	conn, err := net.DialTimeout("tcp", net.JoinHostPort("127.0.0.1", strconv.Itoa(port)), 500*time.Millisecond)
+	if err != nil {
+		return false
+	}
+	_ = conn.Close()
+	return true
+}
+
+func colorEnabled() bool {
+	if os.Getenv("NO_COLOR") != "" {
+		return false
+	}
+	fi, err := os.Stdout.Stat()
+	if err != nil {
+		return false
+	}
+	return fi.Mode()&os.ModeCharDevice != 0
+}
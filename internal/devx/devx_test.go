@@ -0,0 +1,119 @@
+package devx
+
+import (
+	"context"
+	"errors"
+	"io"
+	"reflect"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/b-jonathan/taco/internal/execx"
+	"github.com/b-jonathan/taco/internal/stacks"
+)
+
+func TestRestartsCrashed(t *testing.T) {
+	const quick = time.Second
+	tests := []struct {
+		name string
+		runs []time.Duration
+		want []time.Duration // 0: gives up
+	}{
+		{
+			name: "backoff doubles until it gives up",
+			runs: []time.Duration{quick, quick, quick, quick, quick, quick},
+			want: []time.Duration{500 * time.Millisecond, time.Second, 2 * time.Second, 4 * time.Second, 8 * time.Second, 0},
+		},
+		{
+			name: "a stable run starts over",
+			runs: []time.Duration{quick, quick, time.Minute, quick},
+			want: []time.Duration{500 * time.Millisecond, time.Second, 500 * time.Millisecond, time.Second},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var r restarts
+			var got []time.Duration
+			for _, ran := range tt.runs {
+				backoff, ok := r.crashed(ran)
+				if !ok {
+					backoff = 0
+				}
+				got = append(got, backoff)
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("backoffs = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestSupervise(t *testing.T) {
+	exit1 := errors.New("exit status 1")
+	tests := []struct {
+		name     string
+		run      func(cancel context.CancelFunc, call int) error
+		wantErr  string // empty: returns nil
+		wantRuns int
+	}{
+		{
+			name:     "clean exits count as crashes",
+			run:      func(context.CancelFunc, int) error { return nil },
+			wantErr:  "api crashed 6 times in a row, giving up: exited with status 0",
+			wantRuns: 6,
+		},
+		{
+			name:     "failing command",
+			run:      func(context.CancelFunc, int) error { return exit1 },
+			wantErr:  "api crashed 6 times in a row, giving up: exit status 1",
+			wantRuns: 6,
+		},
+		{
+			name: "cancelled while running",
+			run: func(cancel context.CancelFunc, call int) error {
+				if call == 2 {
+					cancel()
+				}
+				return exit1
+			},
+			wantRuns: 2,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx, cancel := context.WithCancel(context.Background())
+			defer cancel()
+
+			runs := 0
+			runCmd = func(context.Context, string, string, io.Writer, io.Writer) error {
+				runs++
+				return tt.run(cancel, runs)
+			}
+			after = func(time.Duration) <-chan time.Time {
+				ch := make(chan time.Time, 1)
+				ch <- time.Time{}
+				return ch
+			}
+			t.Cleanup(func() { runCmd, after = execx.RunCmdStream, time.After })
+
+			var out strings.Builder
+			w := &prefixWriter{mu: &sync.Mutex{}, out: &out, prefix: "api | "}
+			err := supervise(ctx, stacks.Service{Name: "api", Cmd: "npm run dev"}, w)
+
+			switch {
+			case tt.wantErr == "" && err != nil:
+				t.Errorf("err = %v, want nil", err)
+			case tt.wantErr != "" && (err == nil || err.Error() != tt.wantErr):
+				t.Errorf("err = %v, want %q", err, tt.wantErr)
+			}
+			if runs != tt.wantRuns {
+				t.Errorf("runs = %d, want %d", runs, tt.wantRuns)
+			}
+			if strings.Contains(out.String(), "%!") {
+				t.Errorf("malformed output:\n%s", out.String())
+			}
+		})
+	}
+}
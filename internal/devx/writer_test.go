@@ -0,0 +1,75 @@
+package devx
+
+import (
+	"strings"
+	"sync"
+	"testing"
+)
+
+func TestPrefixWriter(t *testing.T) {
+	tests := []struct {
+		name   string
+		writes []string
+		flush  bool
+		want   string
+	}{
+		{
+			name:   "one line per write",
+			writes: []string{"listening\n", "ready\n"},
+			want:   "api | listening\napi | ready\n",
+		},
+		{
+			name:   "lines split across writes",
+			writes: []string{"liste", "ning\nre", "ady\n"},
+			want:   "api | listening\napi | ready\n",
+		},
+		{
+			name:   "CRLF line endings",
+			writes: []string{"listening\r\n"},
+			want:   "api | listening\n",
+		},
+		{
+			name:   "partial line waits for a newline",
+			writes: []string{"listening\nrea"},
+			want:   "api | listening\n",
+		},
+		{
+			name:   "flush writes the partial line",
+			writes: []string{"listening\nrea"},
+			flush:  true,
+			want:   "api | listening\napi | rea\n",
+		},
+		{
+			name:  "flush without output",
+			flush: true,
+			want:  "",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var out strings.Builder
+			w := &prefixWriter{mu: &sync.Mutex{}, out: &out, prefix: "api | "}
+			for _, s := range tt.writes {
+				n, err := w.Write([]byte(s))
+				if err != nil || n != len(s) {
+					t.Fatalf("Write(%q) = %d, %v", s, n, err)
+				}
+			}
+			if tt.flush {
+				w.Flush()
+			}
+			if got := out.String(); got != tt.want {
+				t.Errorf("output = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestPrefixWriterPrintf(t *testing.T) {
+	var out strings.Builder
+	w := &prefixWriter{mu: &sync.Mutex{}, out: &out, prefix: "api | "}
+	w.Printf("ready on port %d", 4000)
+	if want := "api | [taco] ready on port 4000\n"; out.String() != want {
+		t.Errorf("output = %q, want %q", out.String(), want)
+	}
+}
@@ -0,0 +1,101 @@
+package envsync
+
+import (
+	"fmt"
+	"os"
+	"path"
+	"path/filepath"
+	"sort"
+
+	"github.com/b-jonathan/taco/internal/fsutil"
+	"github.com/b-jonathan/taco/internal/logx"
+	"github.com/joho/godotenv"
+)
+
+// Files are the env files stacks write, relative to the project root.
+var Files = []string{
+	filepath.Join("backend", ".env"),
+	filepath.Join("frontend", ".env"),
+	filepath.Join("frontend", ".env.local"),
+}
+
+type Entry struct {
+	Key    string
+	Value  string
+	Source string // env file the value came from
+}
+
+// Collect reads every existing env file under projectRoot. When a key appears
+// in more than one file the first value wins.
+func Collect(projectRoot string, files []string) ([]Entry, error) {
+	seen := map[string]Entry{}
+	var out []Entry
+	for _, rel := range files {
+		f, err := fsutil.Fs.Open(filepath.Join(projectRoot, rel))
+		if os.IsNotExist(err) {
+			continue
+		}
+		if err != nil {
+			return nil, fmt.Errorf("open %s: %w", rel, err)
+		}
+		vars, err := godotenv.Parse(f)
+		_ = f.Close()
+		if err != nil {
+			return nil, fmt.Errorf("parse %s: %w", rel, err)
+		}
+
+		keys := make([]string, 0, len(vars))
+		for k := range vars {
+			keys = append(keys, k)
+		}
+		sort.Strings(keys)
+		for _, k := range keys {
+			if prev, ok := seen[k]; ok {
+				if prev.Value != vars[k] {
+					logx.Warnf("%s is set in both %s and %s, using %s", k, prev.Source, rel, prev.Source)
+				}
+				continue
+			}
+			e := Entry{Key: k, Value: vars[k], Source: rel}
+			seen[k] = e
+			out = append(out, e)
+		}
+	}
+	return out, nil
+}
+
+// Filter keeps entries matching any allow pattern (all when allow is empty)
+// and drops those matching a deny pattern. Patterns are globs like NEXT_PUBLIC_*.
+func Filter(entries []Entry, allow, deny []string) ([]Entry, error) {
+	for _, p := range append(append([]string{}, allow...), deny...) {
+		if _, err := path.Match(p, ""); err != nil {
+			return nil, fmt.Errorf("bad pattern %q: %w", p, err)
+		}
+	}
+
+	var out []Entry
+	for _, e := range entries {
+		if len(allow) > 0 && !matchAny(allow, e.Key) {
+			continue
+		}
+		if matchAny(deny, e.Key) {
+			continue
+		}
+		out = append(out, e)
+	}
+	return out, nil
+}
+
+func matchAny(patterns []string, key string) bool {
+	for _, p := range patterns {
+		if ok, _ := path.Match(p, key); ok {
+			return true
+		}
+	}
+	return false
+}
+
+// Mask hides a value for listings, keeping only its length.
+func Mask(value string) string {
+	return fmt.Sprintf("**** (%d chars)", len(value))
+}
@@ -9,6 +9,7 @@ import (
 	"os/exec"
 	"runtime"
 	"strings"
+	"time"
 )
 
 // TODO: Make a helper so that you can run whole strings instead of a bunch of strings.
@@ -49,6 +50,31 @@ func RunCmdLive(ctx context.Context, dir string, cmd string) error {
 	return nil
 }
 
+// Used for long-running processes (like dev servers) whose output is forwarded
+// to the given writers. Cancelling ctx interrupts the process and gives it a
+// few seconds to shut down before it is killed.
+func RunCmdStream(ctx context.Context, dir string, cmd string, stdout, stderr io.Writer) error {
+	cmdArgs := strings.Split(cmd, " ")
+	name := cmdArgs[0]
+	args := cmdArgs[1:]
+	c := exec.CommandContext(ctx, name, args...)
+	c.Dir = dir
+	c.Stdout = stdout
+	c.Stderr = stderr
+	c.Cancel = func() error {
+		if runtime.GOOS == "windows" {
+			return c.Process.Kill()
+		}
+		return c.Process.Signal(os.Interrupt)
+	}
+	c.WaitDelay = 5 * time.Second
+
+	if err := c.Run(); err != nil {
+		return fmt.Errorf("%s %v failed: %v", name, args, err)
+	}
+	return nil
+}
+
 func RunCmdOutput(ctx context.Context, dir string, cmd string) (string, string, error) {
 	cmdArgs := strings.Split(cmd, " ")
 	name := cmdArgs[0]
@@ -66,6 +92,24 @@ func RunCmdOutput(ctx context.Context, dir string, cmd string) (string, string,
 
 	return out.String(), errb.String(), nil
 }
+
+// RunArgs runs name with args as given, without splitting on spaces, so
+// arguments such as commit messages survive intact. env entries are added to
+// the inherited environment. It returns stdout.
+func RunArgs(ctx context.Context, dir string, env []string, name string, args ...string) (string, error) {
+	c := exec.CommandContext(ctx, name, args...)
+	c.Dir = dir
+	if len(env) > 0 {
+		c.Env = append(os.Environ(), env...)
+	}
+	var out, errb bytes.Buffer
+	c.Stdout, c.Stderr = &out, &errb
+	if err := c.Run(); err != nil {
+		return out.String(), fmt.Errorf("%s %v failed: %v\nstderr:\n%s", name, args, err, errb.String())
+	}
+	return out.String(), nil
+}
+
 func OpenBrowser(url string) error {
 	var cmd string
 	var args []string
@@ -89,6 +89,11 @@ func RemoveDir(path string) error {
 }
 
 func RenderTemplate(tmplPath string) ([]byte, error) {
+	return RenderTemplateData(tmplPath, nil)
+}
+
+// RenderTemplateData is RenderTemplate with data passed to the template (e.g. {{ .AppName }}).
+func RenderTemplateData(tmplPath string, vars any) ([]byte, error) {
 	data, err := templates.FS.ReadFile(tmplPath)
 	if err != nil {
 		return nil, fmt.Errorf("read embedded template %s: %w", tmplPath, err)
@@ -100,7 +105,7 @@ func RenderTemplate(tmplPath string) ([]byte, error) {
 		return nil, fmt.Errorf("parse template %s: %w", tmplPath, err)
 	}
 	var buf bytes.Buffer
-	if err := tmpl.Execute(&buf, nil); err != nil {
+	if err := tmpl.Execute(&buf, vars); err != nil {
 		return nil, fmt.Errorf("execute template %s: %w", tmplPath, err)
 	}
 	return buf.Bytes(), nil
@@ -145,6 +150,11 @@ func ValidateDependency(stack, dependency string) bool {
 }
 
 func GenerateFromTemplateDir(templateRoot, outputRoot string) error {
+	return GenerateFromTemplateDirData(templateRoot, outputRoot, nil)
+}
+
+// GenerateFromTemplateDirData is GenerateFromTemplateDir with data passed to every template.
+func GenerateFromTemplateDirData(templateRoot, outputRoot string, vars any) error {
 	return fs.WalkDir(templates.FS, templateRoot, func(path string, d fs.DirEntry, err error) error {
 		if err != nil {
 			return err
@@ -166,7 +176,7 @@ func GenerateFromTemplateDir(templateRoot, outputRoot string) error {
 		outputRel := strings.TrimSuffix(relPath, ".tmpl")
 		finalPath := filepath.Join(outputRoot, outputRel)
 
-		content, err := RenderTemplate(path)
+		content, err := RenderTemplateData(path, vars)
 		if err != nil {
 			return fmt.Errorf("render template %s: %w", path, err)
 		}
@@ -178,3 +188,53 @@ func GenerateFromTemplateDir(templateRoot, outputRoot string) error {
 		return afero.WriteFile(Fs, finalPath, content, 0644)
 	})
 }
+
+// InsertAtAnchor inserts text above the line of path containing anchor (e.g.
+// "[DATABASE ROUTE]"), indented like that line. The anchor stays, so several
+// stacks can insert at it. Text that is already present is not inserted again.
+func InsertAtAnchor(path, anchor, text string) error {
+	buf, err := afero.ReadFile(Fs, path)
+	if err != nil {
+		return fmt.Errorf("read %s: %w", path, err)
+	}
+	src := string(buf)
+	text = strings.Trim(text, "\n")
+	if text == "" {
+		return nil
+	}
+
+	lines := strings.Split(src, "\n")
+	for i, line := range lines {
+		if !strings.Contains(line, anchor) {
+			continue
+		}
+		indent := line[:len(line)-len(strings.TrimLeft(line, " \t"))]
+		var insert []string
+		for _, l := range strings.Split(text, "\n") {
+			if l != "" {
+				l = indent + l
+			}
+			insert = append(insert, l)
+		}
+		if strings.Contains(src, strings.Join(insert, "\n")) {
+			return nil
+		}
+		lines = append(lines[:i], append(insert, lines[i:]...)...)
+		return afero.WriteFile(Fs, path, []byte(strings.Join(lines, "\n")), 0o644)
+	}
+	return fmt.Errorf("anchor %s not found in %s", anchor, path)
+}
+
+// HasMakeTarget reports whether dir/Makefile defines target.
+func HasMakeTarget(dir, target string) bool {
+	buf, err := afero.ReadFile(Fs, filepath.Join(dir, "Makefile"))
+	if err != nil {
+		return false
+	}
+	for _, line := range strings.Split(string(buf), "\n") {
+		if name, _, ok := strings.Cut(line, ":"); ok && strings.TrimSpace(name) == target && !strings.HasPrefix(line, "\t") {
+			return true
+		}
+	}
+	return false
+}
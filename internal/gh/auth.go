@@ -0,0 +1,136 @@
+package gh
+
+import (
+	"bufio"
+	"context"
+	"errors"
+	"fmt"
+	"net/url"
+	"os"
+	"os/exec"
+	"strings"
+
+	"github.com/google/go-github/v55/github"
+)
+
+// RequiredScopes returns the classic token scopes init needs. Pushing to a
+// repository only needs repo; when init creates the repository it also needs
+// delete_repo, to remove it again when the push fails.
+func RequiredScopes(create bool) []string {
+	if create {
+		return []string{"repo", "delete_repo"}
+	}
+	return []string{"repo"}
+}
+
+// Token is a resolved credential and where it came from, for messages.
+type Token struct {
+	Value  string
+	Source string // "--token", "GITHUB_TOKEN", "GH_TOKEN", "git credential", "gh auth token"
+}
+
+// ResolveToken walks the credential chain: the explicit token, GITHUB_TOKEN,
+// GH_TOKEN, git credential helpers and finally the gh CLI when it is already
+// installed. host is the GitHub hostname ("github.com" or an Enterprise host).
+func ResolveToken(ctx context.Context, explicit, host string) (Token, error) {
+	if explicit != "" {
+		return Token{explicit, "--token"}, nil
+	}
+	for _, key := range []string{"GITHUB_TOKEN", "GH_TOKEN"} {
+		if v := strings.TrimSpace(os.Getenv(key)); v != "" {
+			return Token{v, key}, nil
+		}
+	}
+	if host == "" {
+		host = "github.com"
+	}
+	if v := credentialHelperToken(ctx, host); v != "" {
+		return Token{v, "git credential"}, nil
+	}
+	if v := ghCLIToken(ctx, host); v != "" {
+		return Token{v, "gh auth token"}, nil
+	}
+	return Token{}, fmt.Errorf("no GitHub token found for %s: pass --token, set GITHUB_TOKEN or GH_TOKEN, store one in a git credential helper, or run `gh auth login`", host)
+}
+
+// credentialHelperToken asks git's configured credential helpers for the host
+// password without ever prompting.
+func credentialHelperToken(ctx context.Context, host string) string {
+	if _, err := exec.LookPath("git"); err != nil {
+		return ""
+	}
+	c := exec.CommandContext(ctx, "git", "credential", "fill")
+	c.Stdin = strings.NewReader("protocol=https\nhost=" + host + "\n\n")
+	c.Env = append(os.Environ(), "GIT_TERMINAL_PROMPT=0", "GCM_INTERACTIVE=never", "GIT_ASKPASS=")
+	out, err := c.Output()
+	if err != nil {
+		return ""
+	}
+	sc := bufio.NewScanner(strings.NewReader(string(out)))
+	for sc.Scan() {
+		if v, ok := strings.CutPrefix(sc.Text(), "password="); ok {
+			return v
+		}
+	}
+	return ""
+}
+
+// ghCLIToken reads the token of an already authenticated gh CLI. It never
+// installs gh or starts a login.
+func ghCLIToken(ctx context.Context, host string) string {
+	if _, err := exec.LookPath("gh"); err != nil {
+		return ""
+	}
+	out, err := exec.CommandContext(ctx, "gh", "auth", "token", "--hostname", host).Output()
+	if err != nil {
+		return ""
+	}
+	return strings.TrimSpace(string(out))
+}
+
+// HostFromBaseURL returns the hostname credentials are stored under for a
+// GitHub Enterprise base URL, or github.com when baseURL is empty.
+func HostFromBaseURL(baseURL string) string {
+	if baseURL == "" {
+		return "github.com"
+	}
+	u, err := url.Parse(baseURL)
+	if err != nil || u.Host == "" {
+		return baseURL
+	}
+	return u.Host
+}
+
+// ValidateToken checks the token against the API and returns the login. Classic
+// tokens report their scopes, which must include scopes (see RequiredScopes);
+// fine-grained and app tokens don't, so their permissions can only fail later.
+func ValidateToken(ctx context.Context, client *github.Client, tok Token, scopes []string) (string, error) {
+	user, resp, err := client.Users.Get(ctx, "")
+	if err != nil {
+		var errResp *github.ErrorResponse
+		if errors.As(err, &errResp) && errResp.Response.StatusCode == 401 {
+			return "", fmt.Errorf("GitHub token from %s is invalid or expired", tok.Source)
+		}
+		return "", fmt.Errorf("validate GitHub token from %s: %w", tok.Source, err)
+	}
+	header, ok := resp.Header["X-Oauth-Scopes"]
+	if !ok {
+		return user.GetLogin(), nil
+	}
+	granted := map[string]bool{}
+	for _, h := range header {
+		for _, s := range strings.Split(h, ",") {
+			granted[strings.TrimSpace(s)] = true
+		}
+	}
+	var missing []string
+	for _, s := range scopes {
+		if !granted[s] {
+			missing = append(missing, s)
+		}
+	}
+	if len(missing) > 0 {
+		return "", fmt.Errorf("GitHub token from %s is missing scopes %s (has: %s)", tok.Source, strings.Join(missing, ", "), strings.Join(header, ","))
+	}
+	return user.GetLogin(), nil
+}
@@ -0,0 +1,35 @@
+package gh
+
+import (
+	"io"
+	"net/http"
+	"strings"
+	"testing"
+)
+
+func TestValidateTokenScopes(t *testing.T) {
+	ctx, _ := newFakeGitHub(t, map[string]func(http.ResponseWriter){
+		"GET /user": func(w http.ResponseWriter) {
+			w.Header().Set("X-OAuth-Scopes", "repo, read:org")
+			_, _ = io.WriteString(w, `{"login":"octocat"}`)
+		},
+	})
+	client, err := FromContext(ctx)
+	if err != nil {
+		t.Fatal(err)
+	}
+	tok := Token{Value: "t", Source: "--token"}
+
+	login, err := ValidateToken(ctx, client, tok, RequiredScopes(false))
+	if err != nil {
+		t.Fatalf("pushing to an existing repo: %v", err)
+	}
+	if login != "octocat" {
+		t.Errorf("login = %q", login)
+	}
+
+	_, err = ValidateToken(ctx, client, tok, RequiredScopes(true))
+	if err == nil || !strings.Contains(err.Error(), "delete_repo") {
+		t.Errorf("creating a repo without delete_repo: err = %v", err)
+	}
+}
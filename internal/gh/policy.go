@@ -0,0 +1,70 @@
+package gh
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+
+	"github.com/b-jonathan/taco/internal/policy"
+	github "github.com/google/go-github/v55/github"
+)
+
+// ApplyPolicy configures merge strategies, labels and branch protection. It
+// keeps going after a failed step and returns all errors joined, since each
+// setting is independent (e.g. protection needs a paid plan on private repos).
+func ApplyPolicy(ctx context.Context, owner, repo string, p policy.Policy) error {
+	client, err := EnsureClient(ctx)
+	if err != nil {
+		return err
+	}
+
+	var errs []error
+
+	_, _, err = client.Repositories.Edit(ctx, owner, repo, &github.Repository{
+		AllowMergeCommit:    github.Bool(p.AllowMergeCommit),
+		AllowSquashMerge:    github.Bool(p.AllowSquashMerge),
+		AllowRebaseMerge:    github.Bool(p.AllowRebaseMerge),
+		DeleteBranchOnMerge: github.Bool(p.DeleteBranchOnMerge),
+	})
+	if err != nil {
+		errs = append(errs, fmt.Errorf("merge settings: %w", err))
+	}
+
+	for _, l := range p.Labels {
+		label := &github.Label{
+			Name:        github.String(l.Name),
+			Color:       github.String(l.Color),
+			Description: github.String(l.Description),
+		}
+		_, resp, err := client.Issues.CreateLabel(ctx, owner, repo, label)
+		if resp != nil && resp.StatusCode == http.StatusUnprocessableEntity {
+			// default labels already exist on new repos
+			_, _, err = client.Issues.EditLabel(ctx, owner, repo, l.Name, label)
+		}
+		if err != nil {
+			errs = append(errs, fmt.Errorf("label %s: %w", l.Name, err))
+		}
+	}
+
+	req := &github.ProtectionRequest{EnforceAdmins: p.EnforceAdmins}
+	if len(p.RequiredChecks) > 0 {
+		checks := make([]*github.RequiredStatusCheck, len(p.RequiredChecks))
+		for i, c := range p.RequiredChecks {
+			checks[i] = &github.RequiredStatusCheck{Context: c}
+		}
+		req.RequiredStatusChecks = &github.RequiredStatusChecks{Strict: p.StrictChecks, Checks: checks}
+	}
+	if p.RequiredApprovals > 0 {
+		req.RequiredPullRequestReviews = &github.PullRequestReviewsEnforcementRequest{
+			RequiredApprovingReviewCount: p.RequiredApprovals,
+			RequireCodeOwnerReviews:      p.RequireCodeOwnerReviews,
+			DismissStaleReviews:          p.DismissStaleReviews,
+		}
+	}
+	if _, _, err := client.Repositories.UpdateBranchProtection(ctx, owner, repo, p.Branch, req); err != nil {
+		errs = append(errs, fmt.Errorf("protect %s: %w", p.Branch, err))
+	}
+
+	return errors.Join(errs...)
+}
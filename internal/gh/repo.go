@@ -14,6 +14,13 @@ type CreateRepoOptions struct {
 	Private     bool
 	Description string
 	Timeout     time.Duration
+
+	Org            string // create under this organization instead of the user account
+	Visibility     string // "public", "private" or "internal" (org only); overrides Private
+	Team           string // team slug granted access (org only)
+	TeamPermission string // pull, triage, push, maintain or admin
+	Topics         []string
+	Homepage       string
 }
 
 // Create Repo
@@ -32,14 +39,51 @@ func CreateRepo(ctx context.Context, opts CreateRepoOptions) (*github.Repository
 
 	newRepo := &github.Repository{
 		Name:        github.String(opts.Name),
-		Private:     github.Bool(opts.Private),
 		Description: github.String(opts.Description),
 	}
+	if opts.Visibility != "" {
+		newRepo.Visibility = github.String(opts.Visibility)
+	} else {
+		newRepo.Private = github.Bool(opts.Private)
+	}
+	if opts.Homepage != "" {
+		newRepo.Homepage = github.String(opts.Homepage)
+	}
 
-	repo, _, err := client.Repositories.Create(ctx, "", newRepo)
+	// an empty org creates the repo in the authenticated user's account
+	repo, _, err := client.Repositories.Create(ctx, opts.Org, newRepo)
 	if err != nil {
 		return nil, fmt.Errorf("create repo: %w", err)
 	}
+	owner := repo.GetOwner().GetLogin()
+
+	// don't leave a half-configured repo behind
+	fail := func(err error) (*github.Repository, error) {
+		if derr := DeleteRepo(ctx, repo); derr != nil {
+			err = fmt.Errorf("%w (cleanup: %v)", err, derr)
+		}
+		return nil, err
+	}
+
+	if len(opts.Topics) > 0 {
+		topics, _, err := client.Repositories.ReplaceAllTopics(ctx, owner, repo.GetName(), opts.Topics)
+		if err != nil {
+			return fail(fmt.Errorf("set topics: %w", err))
+		}
+		repo.Topics = topics
+	}
+
+	if opts.Team != "" {
+		perm := opts.TeamPermission
+		if perm == "" {
+			perm = "push"
+		}
+		_, err := client.Teams.AddTeamRepoBySlug(ctx, opts.Org, opts.Team, owner, repo.GetName(),
+			&github.TeamAddTeamRepoOptions{Permission: perm})
+		if err != nil {
+			return fail(fmt.Errorf("grant team %s %s access: %w", opts.Team, perm, err))
+		}
+	}
 
 	return repo, nil
 }
@@ -55,8 +99,9 @@ func DeleteRepo(ctx context.Context, repo *github.Repository) error {
 		return nil
 	}
 
-	owner := ""
-	if repo.GetOwner() != nil {
+	// org repos are owned by the org, not by the user who created them
+	owner := repo.GetOrganization().GetLogin()
+	if owner == "" && repo.GetOwner() != nil {
 		owner = repo.GetOwner().GetLogin()
 	}
 
@@ -78,3 +123,34 @@ func DeleteRepo(ctx context.Context, repo *github.Repository) error {
 
 	return nil
 }
+
+// GetRepo looks up an existing repository.
+func GetRepo(ctx context.Context, owner, name string) (*github.Repository, error) {
+	client, err := EnsureClient(ctx)
+	if err != nil {
+		return nil, err
+	}
+	repo, _, err := client.Repositories.Get(ctx, owner, name)
+	if err != nil {
+		return nil, fmt.Errorf("get repo %s/%s: %w", owner, name, err)
+	}
+	return repo, nil
+}
+
+// CreatePullRequest opens a pull request from head into base.
+func CreatePullRequest(ctx context.Context, owner, repo, head, base, title, body string) (*github.PullRequest, error) {
+	client, err := EnsureClient(ctx)
+	if err != nil {
+		return nil, err
+	}
+	pr, _, err := client.PullRequests.Create(ctx, owner, repo, &github.NewPullRequest{
+		Title: github.String(title),
+		Head:  github.String(head),
+		Base:  github.String(base),
+		Body:  github.String(body),
+	})
+	if err != nil {
+		return nil, fmt.Errorf("open pull request: %w", err)
+	}
+	return pr, nil
+}
@@ -0,0 +1,185 @@
+package gh
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"reflect"
+	"sync"
+	"testing"
+
+	github "github.com/google/go-github/v55/github"
+)
+
+type call struct {
+	Method string
+	Path   string
+	Body   map[string]any
+}
+
+// fakeGitHub records API calls and answers them from routes, keyed by
+// "METHOD /path". Unrouted calls get a 404.
+type fakeGitHub struct {
+	mu     sync.Mutex
+	calls  []call
+	routes map[string]func(w http.ResponseWriter)
+}
+
+func newFakeGitHub(t *testing.T, routes map[string]func(w http.ResponseWriter)) (context.Context, *fakeGitHub) {
+	t.Helper()
+	f := &fakeGitHub{routes: routes}
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		c := call{Method: r.Method, Path: r.URL.Path}
+		if b, _ := io.ReadAll(r.Body); len(b) > 0 {
+			if err := json.Unmarshal(b, &c.Body); err != nil {
+				t.Errorf("%s %s: body is not a JSON object: %s", r.Method, r.URL.Path, b)
+			}
+		}
+		f.mu.Lock()
+		f.calls = append(f.calls, c)
+		f.mu.Unlock()
+
+		if h, ok := f.routes[r.Method+" "+r.URL.Path]; ok {
+			h(w)
+			return
+		}
+		http.NotFound(w, r)
+	}))
+	t.Cleanup(srv.Close)
+
+	client := github.NewClient(nil)
+	client.BaseURL, _ = url.Parse(srv.URL + "/")
+	return WithContext(context.Background(), client), f
+}
+
+func (f *fakeGitHub) find(method, path string) (call, bool) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	for _, c := range f.calls {
+		if c.Method == method && c.Path == path {
+			return c, true
+		}
+	}
+	return call{}, false
+}
+
+func respond(status int, body string) func(w http.ResponseWriter) {
+	return func(w http.ResponseWriter) {
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(status)
+		_, _ = io.WriteString(w, body)
+	}
+}
+
+const createdRepo = `{"name":"app","full_name":"acme/app","owner":{"login":"acme"},"organization":{"login":"acme"}}`
+
+func TestCreateRepoUserAccount(t *testing.T) {
+	ctx, f := newFakeGitHub(t, map[string]func(http.ResponseWriter){
+		"POST /user/repos": respond(http.StatusCreated, `{"name":"app","full_name":"me/app","owner":{"login":"me"}}`),
+	})
+
+	repo, err := CreateRepo(ctx, CreateRepoOptions{Name: "app", Private: true, Description: "demo"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if repo.GetFullName() != "me/app" {
+		t.Errorf("full name = %q, want me/app", repo.GetFullName())
+	}
+	c, ok := f.find(http.MethodPost, "/user/repos")
+	if !ok {
+		t.Fatal("no POST /user/repos")
+	}
+	if c.Body["name"] != "app" || c.Body["private"] != true || c.Body["description"] != "demo" {
+		t.Errorf("create body = %v", c.Body)
+	}
+	if _, ok := c.Body["visibility"]; ok {
+		t.Errorf("visibility sent without --visibility: %v", c.Body)
+	}
+	if len(f.calls) != 1 {
+		t.Errorf("calls = %v, want only the create", f.calls)
+	}
+}
+
+func TestCreateRepoOrganization(t *testing.T) {
+	ctx, f := newFakeGitHub(t, map[string]func(http.ResponseWriter){
+		"POST /orgs/acme/repos":                    respond(http.StatusCreated, createdRepo),
+		"PUT /repos/acme/app/topics":               respond(http.StatusOK, `{"names":["go","cli"]}`),
+		"PUT /orgs/acme/teams/devs/repos/acme/app": respond(http.StatusNoContent, ``),
+	})
+
+	repo, err := CreateRepo(ctx, CreateRepoOptions{
+		Name:           "app",
+		Org:            "acme",
+		Visibility:     "internal",
+		Team:           "devs",
+		TeamPermission: "maintain",
+		Topics:         []string{"go", "cli"},
+		Homepage:       "https://example.com",
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !reflect.DeepEqual(repo.Topics, []string{"go", "cli"}) {
+		t.Errorf("topics = %v", repo.Topics)
+	}
+
+	c, ok := f.find(http.MethodPost, "/orgs/acme/repos")
+	if !ok {
+		t.Fatal("no POST /orgs/acme/repos")
+	}
+	if c.Body["visibility"] != "internal" || c.Body["homepage"] != "https://example.com" {
+		t.Errorf("create body = %v", c.Body)
+	}
+	if _, ok := c.Body["private"]; ok {
+		t.Errorf("private sent alongside visibility: %v", c.Body)
+	}
+
+	c, ok = f.find(http.MethodPut, "/repos/acme/app/topics")
+	if !ok {
+		t.Fatal("no PUT /repos/acme/app/topics")
+	}
+	if !reflect.DeepEqual(c.Body["names"], []any{"go", "cli"}) {
+		t.Errorf("topics body = %v", c.Body)
+	}
+
+	c, ok = f.find(http.MethodPut, "/orgs/acme/teams/devs/repos/acme/app")
+	if !ok {
+		t.Fatal("no PUT /orgs/acme/teams/devs/repos/acme/app")
+	}
+	if c.Body["permission"] != "maintain" {
+		t.Errorf("team body = %v", c.Body)
+	}
+}
+
+func TestCreateRepoTeamDefaultsToPush(t *testing.T) {
+	ctx, f := newFakeGitHub(t, map[string]func(http.ResponseWriter){
+		"POST /orgs/acme/repos":                    respond(http.StatusCreated, createdRepo),
+		"PUT /orgs/acme/teams/devs/repos/acme/app": respond(http.StatusNoContent, ``),
+	})
+
+	if _, err := CreateRepo(ctx, CreateRepoOptions{Name: "app", Org: "acme", Team: "devs"}); err != nil {
+		t.Fatal(err)
+	}
+	c, _ := f.find(http.MethodPut, "/orgs/acme/teams/devs/repos/acme/app")
+	if c.Body["permission"] != "push" {
+		t.Errorf("team body = %v, want push permission", c.Body)
+	}
+}
+
+func TestCreateRepoDeletesOrgRepoOnFailure(t *testing.T) {
+	ctx, f := newFakeGitHub(t, map[string]func(http.ResponseWriter){
+		"POST /orgs/acme/repos":  respond(http.StatusCreated, createdRepo),
+		"DELETE /repos/acme/app": respond(http.StatusNoContent, ``),
+		// the team grant is unrouted and fails with a 404
+	})
+
+	if _, err := CreateRepo(ctx, CreateRepoOptions{Name: "app", Org: "acme", Team: "missing"}); err == nil {
+		t.Fatal("expected the team grant to fail")
+	}
+	if _, ok := f.find(http.MethodDelete, "/repos/acme/app"); !ok {
+		t.Errorf("half-created repo not deleted; calls = %v", f.calls)
+	}
+}
@@ -0,0 +1,74 @@
+package gh
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/base64"
+	"fmt"
+	"net/http"
+
+	github "github.com/google/go-github/v55/github"
+	"golang.org/x/crypto/nacl/box"
+)
+
+// SetSecret creates or updates an Actions secret. GitHub requires the value to
+// be sealed with the repository's public key (libsodium sealed box).
+func SetSecret(ctx context.Context, owner, repo, name, value string) error {
+	client, err := EnsureClient(ctx)
+	if err != nil {
+		return err
+	}
+
+	key, _, err := client.Actions.GetRepoPublicKey(ctx, owner, repo)
+	if err != nil {
+		return fmt.Errorf("get repo public key: %w", err)
+	}
+
+	encrypted, err := sealSecret(key.GetKey(), value)
+	if err != nil {
+		return err
+	}
+
+	_, err = client.Actions.CreateOrUpdateRepoSecret(ctx, owner, repo, &github.EncryptedSecret{
+		Name:           name,
+		KeyID:          key.GetKeyID(),
+		EncryptedValue: encrypted,
+	})
+	if err != nil {
+		return fmt.Errorf("set secret %s: %w", name, err)
+	}
+	return nil
+}
+
+func sealSecret(publicKey, value string) (string, error) {
+	raw, err := base64.StdEncoding.DecodeString(publicKey)
+	if err != nil || len(raw) != 32 {
+		return "", fmt.Errorf("invalid repo public key")
+	}
+	var pk [32]byte
+	copy(pk[:], raw)
+
+	sealed, err := box.SealAnonymous(nil, []byte(value), &pk, rand.Reader)
+	if err != nil {
+		return "", fmt.Errorf("encrypt secret: %w", err)
+	}
+	return base64.StdEncoding.EncodeToString(sealed), nil
+}
+
+// SetVariable creates or updates a plain-text Actions variable.
+func SetVariable(ctx context.Context, owner, repo, name, value string) error {
+	client, err := EnsureClient(ctx)
+	if err != nil {
+		return err
+	}
+
+	v := &github.ActionsVariable{Name: name, Value: value}
+	resp, err := client.Actions.CreateRepoVariable(ctx, owner, repo, v)
+	if resp != nil && resp.StatusCode == http.StatusConflict {
+		_, err = client.Actions.UpdateRepoVariable(ctx, owner, repo, v)
+	}
+	if err != nil {
+		return fmt.Errorf("set variable %s: %w", name, err)
+	}
+	return nil
+}
@@ -2,77 +2,320 @@ package git
 
 import (
 	"context"
+	"errors"
 	"fmt"
+	"net/mail"
 	"os"
 	"path/filepath"
+	"strings"
 
 	"github.com/b-jonathan/taco/internal/execx"
 	"github.com/b-jonathan/taco/internal/fsutil"
+	"github.com/b-jonathan/taco/internal/logx"
 )
 
-// TODO: There is absolutely no reason for init and push to be in one function, gonna have to refactor this for sure
+// Identity is a commit author or committer. The zero value means "use git config".
+type Identity struct {
+	Name  string
+	Email string
+}
+
+func (i Identity) IsZero() bool { return i.Name == "" && i.Email == "" }
+
+// ParseIdentity parses "Name <email>".
+func ParseIdentity(s string) (Identity, error) {
+	addr, err := mail.ParseAddress(s)
+	if err != nil || addr.Name == "" {
+		return Identity{}, fmt.Errorf("identity %q must look like \"Name <email>\"", s)
+	}
+	return Identity{Name: addr.Name, Email: addr.Address}, nil
+}
+
+// Options controls how the scaffold is committed.
+type Options struct {
+	Branch     string   // defaults to main
+	Author     Identity // defaults to git config
+	Committer  Identity // defaults to Author, then git config
+	Sign       string   // "", "gpg" or "ssh"
+	SigningKey string   // gpg key id or ssh public key path; empty uses user.signingkey
+	NoVerify   bool     // skip commit hooks, e.g. ones installed with the scaffold
+}
+
+func (o Options) branch() string {
+	if o.Branch == "" {
+		return "main"
+	}
+	return o.Branch
+}
+
+// Validate rejects unknown signing modes.
+func (o Options) Validate() error {
+	switch o.Sign {
+	case "", "gpg", "ssh":
+		return nil
+	default:
+		return fmt.Errorf("unknown signing mode %q (allowed: gpg, ssh)", o.Sign)
+	}
+}
+
+// Commit is one commit of the scaffold: the message and the paths it stages,
+// relative to the project root. No paths means everything that is left.
+type Commit struct {
+	Message string
+	Paths   []string
+}
+
+func run(ctx context.Context, dir string, env []string, args ...string) (string, error) {
+	return execx.RunArgs(ctx, dir, env, "git", args...)
+}
+
+// Enclosing returns the top level of the git repository dir already lives in,
+// when that repository starts above dir (e.g. a monorepo subdirectory).
+func Enclosing(ctx context.Context, dir string) (string, bool) {
+	abs, err := filepath.Abs(dir)
+	if err != nil {
+		return "", false
+	}
+	// dir may not exist yet; ask from its closest existing parent
+	probe := abs
+	for {
+		if _, err := os.Stat(probe); err == nil {
+			break
+		}
+		parent := filepath.Dir(probe)
+		if parent == probe {
+			return "", false
+		}
+		probe = parent
+	}
+	out, err := run(ctx, probe, nil, "rev-parse", "--show-toplevel")
+	if err != nil {
+		return "", false
+	}
+	top := strings.TrimSpace(out)
+	if resolved, err := filepath.EvalSymlinks(abs); err == nil {
+		abs = resolved
+	}
+	if resolved, err := filepath.EvalSymlinks(top); err == nil {
+		top = resolved
+	}
+	return top, top != abs
+}
 
-func Init(ctx context.Context, projectRoot string) error {
+// CheckIdentity makes sure commits can be made: either opts carries an author
+// or git config has user.name and user.email.
+func CheckIdentity(ctx context.Context, dir string, opts Options) error {
+	if !opts.Author.IsZero() {
+		if opts.Author.Name == "" || opts.Author.Email == "" {
+			return errors.New("git author needs both a name and an email")
+		}
+		return nil
+	}
+	var missing []string
+	for _, key := range []string{"user.name", "user.email"} {
+		if out, err := run(ctx, dir, nil, "config", "--get", key); err != nil || strings.TrimSpace(out) == "" {
+			missing = append(missing, key)
+		}
+	}
+	if len(missing) > 0 {
+		return fmt.Errorf("git %s not set: run `git config --global user.name \"Your Name\"` and `git config --global user.email you@example.com`, or pass --git-author \"Name <email>\"", strings.Join(missing, " and "))
+	}
+	return nil
+}
+
+// Init creates the repository on opts.Branch. Inside an enclosing repository
+// it does nothing, so the monorepo's history and branch are left alone.
+func Init(ctx context.Context, projectRoot string, opts Options) error {
+	if top, ok := Enclosing(ctx, projectRoot); ok {
+		logx.Infof("%s is inside the git repository at %s, not running git init", projectRoot, top)
+		return nil
+	}
 
 	// If already a repo, skip init
 	if _, err := fsutil.Fs.Stat(filepath.Join(projectRoot, ".git")); os.IsNotExist(err) {
-		if err := execx.RunCmd(ctx, projectRoot, "git init"); err != nil {
+		if _, err := run(ctx, projectRoot, nil, "init"); err != nil {
 			return fmt.Errorf("git init: %w", err)
 		}
 	}
 
-	// Set default branch to main
-	if err := execx.RunCmd(ctx, projectRoot, "git checkout -B main"); err != nil {
-		return fmt.Errorf("git checkout -B main: %w", err)
+	if _, err := run(ctx, projectRoot, nil, "checkout", "-B", opts.branch()); err != nil {
+		return fmt.Errorf("git checkout -B %s: %w", opts.branch(), err)
 	}
 
 	return nil
 }
 
-func Commit(ctx context.Context, projectRoot, commitMsg string) error {
-	// Stage and commit
-	if err := execx.RunCmd(ctx, projectRoot, "git add ."); err != nil {
-		return fmt.Errorf("git add .: %w", err)
+// CommitAll makes each commit in order, skipping those with nothing to stage.
+func CommitAll(ctx context.Context, projectRoot string, commits []Commit, opts Options) error {
+	return commitAll(ctx, projectRoot, commits, opts, false)
+}
+
+// commitAll stages and commits. keepUpstream stages without removals, so files
+// that exist only in a fetched base survive (see InitAndPushOnto).
+func commitAll(ctx context.Context, projectRoot string, commits []Commit, opts Options, keepUpstream bool) error {
+	_, enclosing := Enclosing(ctx, projectRoot)
+	for _, c := range commits {
+		paths := c.Paths
+		if len(paths) == 0 {
+			paths = []string{"."}
+		}
+
+		add := []string{"add"}
+		if keepUpstream {
+			add = append(add, "--ignore-removal")
+		}
+		if _, err := run(ctx, projectRoot, nil, append(append(add, "--"), paths...)...); err != nil {
+			return fmt.Errorf("git add %v: %w", paths, err)
+		}
+
+		// exit status 1 means there are staged changes
+		if _, err := run(ctx, projectRoot, nil, append([]string{"diff", "--cached", "--quiet", "--"}, paths...)...); err == nil {
+			continue
+		}
+
+		args := commitArgs(opts, c.Message)
+		if enclosing {
+			// only commit the project, not whatever else is staged in the monorepo
+			args = append(append(args, "--"), paths...)
+		}
+		if _, err := run(ctx, projectRoot, identityEnv(opts), args...); err != nil {
+			return fmt.Errorf("git commit %q: %w", firstLine(c.Message), err)
+		}
+	}
+	return nil
+}
+
+func commitArgs(opts Options, msg string) []string {
+	var args []string
+	switch opts.Sign {
+	case "ssh":
+		args = append(args, "-c", "gpg.format=ssh")
+	case "gpg":
+		args = append(args, "-c", "gpg.format=openpgp")
+	}
+	if opts.Sign != "" && opts.SigningKey != "" {
+		args = append(args, "-c", "user.signingkey="+opts.SigningKey)
+	}
+	args = append(args, "commit", "-m", msg)
+	if opts.Sign != "" {
+		args = append(args, "-S")
+	}
+	if opts.NoVerify {
+		args = append(args, "--no-verify")
 	}
+	return args
+}
 
-	if err := execx.RunCmd(ctx, projectRoot, "git commit -m "+commitMsg); err != nil {
-		return fmt.Errorf("git commit: %w", err)
+// identityEnv overrides author and committer through the environment, so git
+// config stays untouched.
+func identityEnv(opts Options) []string {
+	committer := opts.Committer
+	if committer.IsZero() {
+		committer = opts.Author
+	}
+	var env []string
+	if !opts.Author.IsZero() {
+		env = append(env, "GIT_AUTHOR_NAME="+opts.Author.Name, "GIT_AUTHOR_EMAIL="+opts.Author.Email)
+	}
+	if !committer.IsZero() {
+		env = append(env, "GIT_COMMITTER_NAME="+committer.Name, "GIT_COMMITTER_EMAIL="+committer.Email)
 	}
+	return env
+}
 
-	return nil
+func firstLine(s string) string {
+	line, _, _ := strings.Cut(s, "\n")
+	return line
+}
 
+// SetRemote points origin at remoteURL, replacing an existing origin.
+func SetRemote(ctx context.Context, projectRoot, remoteURL string) error {
+	_, _ = run(ctx, projectRoot, nil, "remote", "remove", "origin")
+	if _, err := run(ctx, projectRoot, nil, "remote", "add", "origin", remoteURL); err != nil {
+		return fmt.Errorf("git remote add: %w", err)
+	}
+	return nil
 }
 
 func Push(ctx context.Context, projectRoot, remoteURL, branch string) error {
 
 	// Configure remote. If it already exists, update it.
-	_ = execx.RunCmd(ctx, projectRoot, "git remote remove origin")
-	if err := execx.RunCmd(ctx, projectRoot, "git remote add origin "+remoteURL); err != nil {
-		return fmt.Errorf("git remote add: %w", err)
+	if err := SetRemote(ctx, projectRoot, remoteURL); err != nil {
+		return err
 	}
 
 	// Push upstream
-	if err := execx.RunCmd(ctx, projectRoot, "git push -u origin main"); err != nil {
+	if _, err := run(ctx, projectRoot, nil, "push", "-u", "origin", branch); err != nil {
 		return fmt.Errorf("git push: %w", err)
 	}
 
 	return nil
 }
 
-// Calls all 3 of the helper functions. If we don't want this then we will need to
-// change the function call in cli/root.go
-func InitAndPush(ctx context.Context, projectRoot, remoteURL, commitMsg string) error {
-	if err := Init(ctx, projectRoot); err != nil {
+// InitAndPush initializes the repository, makes the commits and pushes
+// opts.Branch. It refuses to run inside an enclosing repository, where
+// replacing origin would rewire the monorepo.
+func InitAndPush(ctx context.Context, projectRoot, remoteURL string, commits []Commit, opts Options) error {
+	if top, ok := Enclosing(ctx, projectRoot); ok {
+		return fmt.Errorf("%s is inside the git repository at %s; push from there instead", projectRoot, top)
+	}
+	if err := Init(ctx, projectRoot, opts); err != nil {
 		return err
 	}
 
-	if err := Commit(ctx, projectRoot, commitMsg); err != nil {
+	if err := CommitAll(ctx, projectRoot, commits, opts); err != nil {
 		return err
 	}
 
-	if err := Push(ctx, projectRoot, remoteURL, "main"); err != nil {
+	if err := Push(ctx, projectRoot, remoteURL, opts.branch()); err != nil {
 		return err
 	}
 
 	return nil
 }
+
+// RemoteBranches lists the branches on remoteURL without cloning it. An empty
+// repository has none.
+func RemoteBranches(ctx context.Context, remoteURL string) ([]string, error) {
+	out, err := run(ctx, "", nil, "ls-remote", "--heads", remoteURL)
+	if err != nil {
+		return nil, fmt.Errorf("git ls-remote %s: %w", remoteURL, err)
+	}
+	var branches []string
+	for _, line := range strings.Split(out, "\n") {
+		if _, ref, ok := strings.Cut(strings.TrimSpace(line), "\t"); ok {
+			branches = append(branches, strings.TrimPrefix(ref, "refs/heads/"))
+		}
+	}
+	return branches, nil
+}
+
+// InitAndPushOnto commits the scaffold on opts.Branch on top of base from
+// remoteURL and pushes it, so it can be merged through a pull request. Files
+// that only exist upstream (README, LICENSE, ...) are kept; files the
+// scaffold also writes show up as changes.
+func InitAndPushOnto(ctx context.Context, projectRoot, remoteURL, base string, commits []Commit, opts Options) error {
+	if top, ok := Enclosing(ctx, projectRoot); ok {
+		return fmt.Errorf("%s is inside the git repository at %s; push from there instead", projectRoot, top)
+	}
+	if err := Init(ctx, projectRoot, opts); err != nil {
+		return err
+	}
+	if err := SetRemote(ctx, projectRoot, remoteURL); err != nil {
+		return err
+	}
+	if _, err := run(ctx, projectRoot, nil, "fetch", "origin", base); err != nil {
+		return fmt.Errorf("git fetch %s: %w", base, err)
+	}
+	// move the branch onto base without touching the working tree
+	if _, err := run(ctx, projectRoot, nil, "reset", "FETCH_HEAD"); err != nil {
+		return fmt.Errorf("git reset onto %s: %w", base, err)
+	}
+	if err := commitAll(ctx, projectRoot, commits, opts, true); err != nil {
+		return err
+	}
+	if _, err := run(ctx, projectRoot, nil, "push", "-u", "origin", opts.branch()); err != nil {
+		return fmt.Errorf("git push: %w", err)
+	}
+	return nil
+}
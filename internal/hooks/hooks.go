@@ -0,0 +1,145 @@
+package hooks
+
+import (
+	"context"
+	"fmt"
+	"os/exec"
+	"path/filepath"
+
+	"github.com/b-jonathan/taco/internal/execx"
+	"github.com/b-jonathan/taco/internal/fsutil"
+	"github.com/b-jonathan/taco/internal/logx"
+	"github.com/b-jonathan/taco/internal/nodepkg"
+	"github.com/spf13/afero"
+)
+
+// Kinds lists the supported hook managers. "git" writes a plain
+// .git/hooks/pre-commit script that is not committed.
+var Kinds = []string{"none", "git", "husky", "lefthook"}
+
+// lintDirs are the project folders checked for a lint-check script or a
+// Makefile lint target.
+var lintDirs = []string{"frontend", "backend"}
+
+// Lint is the lint command run in one project folder.
+type Lint struct {
+	Dir string
+	Run string
+}
+
+type hookData struct {
+	Lints []Lint
+}
+
+// Validate rejects unknown hook kinds.
+func Validate(kind string) error {
+	for _, k := range Kinds {
+		if k == kind {
+			return nil
+		}
+	}
+	return fmt.Errorf("unknown hooks %q. available: %v", kind, Kinds)
+}
+
+// Lints returns the lint command of every project folder that has one: the
+// package.json lint-check script, else the Makefile lint target.
+func Lints(projectRoot string) []Lint {
+	var lints []Lint
+	for _, d := range lintDirs {
+		dir := filepath.Join(projectRoot, d)
+		switch {
+		case nodepkg.HasScript(dir, "lint-check"):
+			lints = append(lints, Lint{Dir: d, Run: "npm run lint-check"})
+		case fsutil.HasMakeTarget(dir, "lint"):
+			lints = append(lints, Lint{Dir: d, Run: "make lint"})
+		}
+	}
+	return lints
+}
+
+// Install sets up a pre-commit hook running every lint-check. It expects an
+// initialized repository at projectRoot; husky and lefthook files are meant
+// to be committed with the scaffold.
+func Install(ctx context.Context, projectRoot, appName, kind string) error {
+	if kind == "" || kind == "none" {
+		return nil
+	}
+	data := hookData{Lints: Lints(projectRoot)}
+	if len(data.Lints) == 0 {
+		logx.Warnf("no lint-check scripts or lint targets found, skipping %s hooks", kind)
+		return nil
+	}
+
+	switch kind {
+	case "git":
+		return writeHook(filepath.Join(projectRoot, ".git", "hooks", "pre-commit"), data)
+	case "lefthook":
+		return installLefthook(ctx, projectRoot, data)
+	case "husky":
+		return installHusky(ctx, projectRoot, appName, data)
+	default:
+		return Validate(kind)
+	}
+}
+
+func writeHook(path string, data hookData) error {
+	content, err := fsutil.RenderTemplateData("hooks/pre-commit.tmpl", data)
+	if err != nil {
+		return fmt.Errorf("render pre-commit hook: %w", err)
+	}
+	if err := fsutil.Fs.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+		return fmt.Errorf("mkdir %s: %w", filepath.Dir(path), err)
+	}
+	if err := afero.WriteFile(fsutil.Fs, path, content, 0o755); err != nil {
+		return fmt.Errorf("write %s: %w", path, err)
+	}
+	return nil
+}
+
+func installLefthook(ctx context.Context, projectRoot string, data hookData) error {
+	content, err := fsutil.RenderTemplateData("hooks/lefthook.yml.tmpl", data)
+	if err != nil {
+		return fmt.Errorf("render lefthook.yml: %w", err)
+	}
+	if err := fsutil.WriteFile(fsutil.FileInfo{Path: filepath.Join(projectRoot, "lefthook.yml"), Content: content}); err != nil {
+		return err
+	}
+
+	cmd := "npx --yes lefthook install"
+	if _, err := exec.LookPath("lefthook"); err == nil {
+		cmd = "lefthook install"
+	}
+	if err := execx.RunCmd(ctx, projectRoot, cmd); err != nil {
+		return fmt.Errorf("lefthook install: %w", err)
+	}
+	return nil
+}
+
+// installHusky adds a private root package.json holding husky, whose prepare
+// script points git at .husky on every npm install.
+func installHusky(ctx context.Context, projectRoot, appName string, data hookData) error {
+	pkgPath := filepath.Join(projectRoot, "package.json")
+	if _, err := fsutil.Fs.Stat(pkgPath); err != nil {
+		pkg := fmt.Sprintf("{\n  \"name\": %q,\n  \"private\": true\n}\n", appName)
+		if err := afero.WriteFile(fsutil.Fs, pkgPath, []byte(pkg), 0o644); err != nil {
+			return fmt.Errorf("write %s: %w", pkgPath, err)
+		}
+	}
+	if err := nodepkg.InitPackage(projectRoot, nodepkg.InitPackageParams{
+		Scripts: map[string]string{"prepare": "husky"},
+	}); err != nil {
+		return fmt.Errorf("add prepare script: %w", err)
+	}
+	_ = fsutil.AppendUniqueLines(filepath.Join(projectRoot, ".gitignore"), []string{"/node_modules/"})
+
+	if err := execx.RunCmd(ctx, projectRoot, "npm install --save-dev husky"); err != nil {
+		return fmt.Errorf("install husky: %w", err)
+	}
+	if err := writeHook(filepath.Join(projectRoot, ".husky", "pre-commit"), data); err != nil {
+		return err
+	}
+	if err := execx.RunCmd(ctx, projectRoot, "npx husky"); err != nil {
+		return fmt.Errorf("husky: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,42 @@
+package manifest
+
+import (
+	"encoding/json"
+	"fmt"
+	"path/filepath"
+
+	"github.com/b-jonathan/taco/internal/fsutil"
+	"github.com/spf13/afero"
+)
+
+// FileName is written at the project root so later commands (like `taco dev`)
+// know which stacks were scaffolded.
+const FileName = "taco.json"
+
+type Manifest struct {
+	Name   string            `json:"name"`
+	Stacks map[string]string `json:"stacks"` // slot ("frontend", "backend", ...) -> stack name
+}
+
+func Write(projectRoot string, m Manifest) error {
+	out, err := json.MarshalIndent(m, "", "  ")
+	if err != nil {
+		return fmt.Errorf("marshal manifest: %w", err)
+	}
+	return fsutil.WriteFile(fsutil.FileInfo{
+		Path:    filepath.Join(projectRoot, FileName),
+		Content: append(out, '\n'),
+	})
+}
+
+func Read(projectRoot string) (Manifest, error) {
+	var m Manifest
+	b, err := afero.ReadFile(fsutil.Fs, filepath.Join(projectRoot, FileName))
+	if err != nil {
+		return m, err
+	}
+	if err := json.Unmarshal(b, &m); err != nil {
+		return m, fmt.Errorf("parse %s: %w", FileName, err)
+	}
+	return m, nil
+}
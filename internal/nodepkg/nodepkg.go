@@ -46,3 +46,25 @@ func InitPackage(dir string, params InitPackageParams) error {
 	}
 	return afero.WriteFile(fsutil.Fs, path, out, 0o644)
 }
+
+// HasScript reports whether dir/package.json defines the given npm script.
+func HasScript(dir, name string) bool {
+	_, ok := Script(dir, name)
+	return ok
+}
+
+// Script returns the command of an npm script in dir/package.json.
+func Script(dir, name string) (string, bool) {
+	b, err := afero.ReadFile(fsutil.Fs, filepath.Join(dir, "package.json"))
+	if err != nil {
+		return "", false
+	}
+	var pkg struct {
+		Scripts map[string]string `json:"scripts"`
+	}
+	if err := json.Unmarshal(b, &pkg); err != nil {
+		return "", false
+	}
+	script, ok := pkg.Scripts[name]
+	return script, ok
+}
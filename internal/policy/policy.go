@@ -0,0 +1,100 @@
+package policy
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"github.com/b-jonathan/taco/internal/fsutil"
+	"github.com/spf13/afero"
+)
+
+// Policy is the repository setup applied after the initial push. It can be
+// loaded from a JSON file; fields missing from the file keep their defaults.
+type Policy struct {
+	Branch string `json:"branch"`
+
+	RequiredApprovals       int      `json:"required_approvals"`
+	RequireCodeOwnerReviews bool     `json:"require_code_owner_reviews"`
+	DismissStaleReviews     bool     `json:"dismiss_stale_reviews"`
+	RequiredChecks          []string `json:"required_checks"`
+	StrictChecks            bool     `json:"strict_checks"` // branch must be up to date before merging
+	EnforceAdmins           bool     `json:"enforce_admins"`
+
+	AllowMergeCommit    bool `json:"allow_merge_commit"`
+	AllowSquashMerge    bool `json:"allow_squash_merge"`
+	AllowRebaseMerge    bool `json:"allow_rebase_merge"`
+	DeleteBranchOnMerge bool `json:"delete_branch_on_merge"`
+
+	Labels     []Label  `json:"labels"`
+	CodeOwners []string `json:"code_owners"` // e.g. "@acme/platform"
+}
+
+type Label struct {
+	Name        string `json:"name"`
+	Color       string `json:"color"` // hex without '#'
+	Description string `json:"description"`
+}
+
+// Default protects branch with one approval, the given status checks and
+// squash-only merges, and deletes head branches after merge.
+func Default(branch string, checks, codeOwners []string) Policy {
+	return Policy{
+		Branch:                  branch,
+		RequiredApprovals:       1,
+		RequireCodeOwnerReviews: len(codeOwners) > 0,
+		DismissStaleReviews:     true,
+		RequiredChecks:          checks,
+		StrictChecks:            true,
+		AllowSquashMerge:        true,
+		DeleteBranchOnMerge:     true,
+		Labels: []Label{
+			{Name: "bug", Color: "d73a4a", Description: "Something isn't working"},
+			{Name: "enhancement", Color: "a2eeef", Description: "New feature or request"},
+			{Name: "documentation", Color: "0075ca", Description: "Improvements or additions to documentation"},
+		},
+		CodeOwners: codeOwners,
+	}
+}
+
+// Load overlays the JSON file at path onto p.
+func Load(path string, p Policy) (Policy, error) {
+	b, err := afero.ReadFile(fsutil.Fs, path)
+	if err != nil {
+		return p, fmt.Errorf("read policy: %w", err)
+	}
+	if err := json.Unmarshal(b, &p); err != nil {
+		return p, fmt.Errorf("parse policy %s: %w", path, err)
+	}
+	if !p.AllowMergeCommit && !p.AllowSquashMerge && !p.AllowRebaseMerge {
+		return p, fmt.Errorf("policy %s disables every merge strategy", path)
+	}
+	return p, nil
+}
+
+// WriteFiles generates the .github files matching the policy: CODEOWNERS,
+// a pull request template and issue templates using the policy labels.
+func WriteFiles(projectRoot string, p Policy) error {
+	out := filepath.Join(projectRoot, ".github")
+	if err := fsutil.GenerateFromTemplateDirData("repofiles", out, p); err != nil {
+		return fmt.Errorf("generate .github files: %w", err)
+	}
+	// an empty CODEOWNERS file is worse than none
+	if len(p.CodeOwners) == 0 {
+		if err := fsutil.Fs.Remove(filepath.Join(out, "CODEOWNERS")); err != nil && !os.IsNotExist(err) {
+			return err
+		}
+	}
+	return nil
+}
+
+// HasLabel reports whether the policy defines the label, for templates.
+func (p Policy) HasLabel(name string) bool {
+	for _, l := range p.Labels {
+		if l.Name == name {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,125 @@
+// Package pypkg manages the dependencies of generated Python projects. A
+// project is a uv project when uv is installed, otherwise a plain .venv
+// managed with pip; pyproject.toml lists the dependencies either way.
+package pypkg
+
+import (
+	"context"
+	"fmt"
+	"os/exec"
+	"path/filepath"
+	"runtime"
+	"strings"
+
+	"github.com/b-jonathan/taco/internal/execx"
+	"github.com/b-jonathan/taco/internal/fsutil"
+	"github.com/spf13/afero"
+)
+
+// VenvDir is the virtualenv of a project, relative to its folder.
+const VenvDir = ".venv"
+
+// HasUV reports whether uv is on PATH.
+func HasUV() bool {
+	_, err := exec.LookPath("uv")
+	return err == nil
+}
+
+// Python returns the interpreter of dir's virtualenv, relative to dir.
+func Python() string {
+	if runtime.GOOS == "windows" {
+		return filepath.Join(VenvDir, "Scripts", "python.exe")
+	}
+	return filepath.Join(VenvDir, "bin", "python")
+}
+
+// CreateVenv creates dir/.venv: `uv venv` when uv is installed, else
+// python3 -m venv.
+func CreateVenv(ctx context.Context, dir string) error {
+	if HasUV() {
+		if err := execx.RunCmd(ctx, dir, "uv venv "+VenvDir); err != nil {
+			return fmt.Errorf("uv venv: %w", err)
+		}
+		return nil
+	}
+	python := "python3"
+	if _, err := exec.LookPath(python); err != nil {
+		python = "python"
+	}
+	if err := execx.RunCmd(ctx, dir, python+" -m venv "+VenvDir); err != nil {
+		return fmt.Errorf("python -m venv: %w", err)
+	}
+	return nil
+}
+
+// Add installs pkgs into dir/.venv and records them in pyproject.toml under
+// [project] dependencies.
+func Add(ctx context.Context, dir string, pkgs ...string) error {
+	return add(ctx, dir, false, pkgs)
+}
+
+// AddDev is Add for the dev dependency group.
+func AddDev(ctx context.Context, dir string, pkgs ...string) error {
+	return add(ctx, dir, true, pkgs)
+}
+
+func add(ctx context.Context, dir string, dev bool, pkgs []string) error {
+	if HasUV() {
+		cmd := "uv add "
+		if dev {
+			cmd += "--dev "
+		}
+		if err := execx.RunCmd(ctx, dir, cmd+strings.Join(pkgs, " ")); err != nil {
+			return fmt.Errorf("uv add: %w", err)
+		}
+		return nil
+	}
+
+	if err := execx.RunCmd(ctx, dir, Python()+" -m pip install "+strings.Join(pkgs, " ")); err != nil {
+		return fmt.Errorf("pip install: %w", err)
+	}
+	list := "dependencies"
+	if dev {
+		list = "dev"
+	}
+	return addToList(filepath.Join(dir, "pyproject.toml"), list, pkgs)
+}
+
+// addToList appends pkgs to the multi-line array `key = [` in a TOML file,
+// skipping those already listed.
+func addToList(path, key string, pkgs []string) error {
+	b, err := afero.ReadFile(fsutil.Fs, path)
+	if err != nil {
+		return fmt.Errorf("read %s: %w", path, err)
+	}
+	lines := strings.Split(string(b), "\n")
+
+	start := -1
+	for i, line := range lines {
+		if strings.TrimSpace(line) == key+" = [" {
+			start = i
+			break
+		}
+	}
+	if start < 0 {
+		return fmt.Errorf("%s: no multi-line %q list", path, key)
+	}
+	end := start + 1
+	for end < len(lines) && strings.TrimSpace(lines[end]) != "]" {
+		end++
+	}
+	if end == len(lines) {
+		return fmt.Errorf("%s: %q list is not closed", path, key)
+	}
+
+	existing := strings.Join(lines[start:end], "\n")
+	var added []string
+	for _, p := range pkgs {
+		entry := fmt.Sprintf("%q,", p)
+		if !strings.Contains(existing, entry) {
+			added = append(added, "    "+entry)
+		}
+	}
+	out := append(append(append([]string{}, lines[:end]...), added...), lines[end:]...)
+	return afero.WriteFile(fsutil.Fs, path, []byte(strings.Join(out, "\n")), 0o644)
+}
@@ -0,0 +1,78 @@
+package remote
+
+import (
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+)
+
+type call struct {
+	Method string
+	Path   string // escaped, as sent
+	Query  string
+	Body   map[string]any
+}
+
+// fakeAPI is an httptest stand-in for a hosting API. It records every call
+// and answers from routes, keyed by "METHOD /escaped/path"; anything
+// unrouted gets a 404.
+type fakeAPI struct {
+	URL string
+
+	mu     sync.Mutex
+	calls  []call
+	routes map[string]func(w http.ResponseWriter)
+}
+
+func newFakeAPI(t *testing.T, routes map[string]func(w http.ResponseWriter)) *fakeAPI {
+	t.Helper()
+	f := &fakeAPI{routes: routes}
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		c := call{Method: r.Method, Path: r.URL.EscapedPath(), Query: r.URL.RawQuery}
+		if b, _ := io.ReadAll(r.Body); len(b) > 0 {
+			if err := json.Unmarshal(b, &c.Body); err != nil {
+				t.Errorf("%s %s: body is not a JSON object: %s", r.Method, c.Path, b)
+			}
+		}
+		f.mu.Lock()
+		f.calls = append(f.calls, c)
+		f.mu.Unlock()
+
+		if h, ok := f.routes[r.Method+" "+c.Path]; ok {
+			h(w)
+			return
+		}
+		http.NotFound(w, r)
+	}))
+	t.Cleanup(srv.Close)
+	f.URL = srv.URL
+	return f
+}
+
+func (f *fakeAPI) find(method, path string) (call, bool) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	for _, c := range f.calls {
+		if c.Method == method && c.Path == path {
+			return c, true
+		}
+	}
+	return call{}, false
+}
+
+func (f *fakeAPI) count() int {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	return len(f.calls)
+}
+
+func respond(status int, body string) func(w http.ResponseWriter) {
+	return func(w http.ResponseWriter) {
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(status)
+		_, _ = io.WriteString(w, body)
+	}
+}
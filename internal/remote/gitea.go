@@ -0,0 +1,195 @@
+package remote
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strings"
+
+	"github.com/b-jonathan/taco/internal/logx"
+)
+
+type gitea struct {
+	api *apiClient
+}
+
+func newGitea(baseURL, token string) *gitea {
+	h := http.Header{}
+	h.Set("Authorization", "token "+token)
+	return &gitea{api: newAPIClient(baseURL+"/api/v1", h)}
+}
+
+func (*gitea) Name() string { return "gitea" }
+
+type giteaRepo struct {
+	Name          string `json:"name"`
+	FullName      string `json:"full_name"`
+	HTMLURL       string `json:"html_url"`
+	SSHURL        string `json:"ssh_url"`
+	CloneURL      string `json:"clone_url"`
+	DefaultBranch string `json:"default_branch"`
+	Owner         struct {
+		Login string `json:"login"`
+	} `json:"owner"`
+}
+
+func (r giteaRepo) repo() *Repo {
+	return &Repo{
+		Owner:         r.Owner.Login,
+		Name:          r.Name,
+		FullName:      r.FullName,
+		HTMLURL:       r.HTMLURL,
+		SSHURL:        r.SSHURL,
+		CloneURL:      r.CloneURL,
+		DefaultBranch: r.DefaultBranch,
+	}
+}
+
+func (g *gitea) CreateRepo(ctx context.Context, opts CreateRepoOptions) (*Repo, error) {
+	visibility := opts.visibility()
+	if visibility == "internal" {
+		return nil, fmt.Errorf("gitea does not support internal visibility")
+	}
+	body := map[string]any{
+		"name":        opts.Name,
+		"description": opts.Description,
+		"private":     visibility == "private",
+	}
+	if opts.DefaultBranch != "" {
+		body["default_branch"] = opts.DefaultBranch
+	}
+
+	path := "/user/repos"
+	if opts.Org != "" {
+		path = "/orgs/" + url.PathEscape(opts.Org) + "/repos"
+	}
+
+	var r giteaRepo
+	if err := g.api.do(ctx, http.MethodPost, path, body, &r); err != nil {
+		return nil, fmt.Errorf("create gitea repo: %w", err)
+	}
+	repo := r.repo()
+
+	if err := g.configure(ctx, repo, opts); err != nil {
+		if derr := g.DeleteRepo(ctx, repo); derr != nil {
+			err = fmt.Errorf("%w (cleanup: %v)", err, derr)
+		}
+		return nil, err
+	}
+	return repo, nil
+}
+
+// configure applies the settings Gitea doesn't accept at creation time.
+func (g *gitea) configure(ctx context.Context, repo *Repo, opts CreateRepoOptions) error {
+	if opts.Homepage != "" {
+		if err := g.api.do(ctx, http.MethodPatch, g.repoPath(repo), map[string]any{"website": opts.Homepage}, nil); err != nil {
+			return fmt.Errorf("set gitea website: %w", err)
+		}
+	}
+	if len(opts.Topics) > 0 {
+		if err := g.api.do(ctx, http.MethodPut, g.repoPath(repo)+"/topics", map[string]any{"topics": opts.Topics}, nil); err != nil {
+			return fmt.Errorf("set gitea topics: %w", err)
+		}
+	}
+	if opts.Team != "" {
+		if opts.Org == "" {
+			return fmt.Errorf("team access requires an organization")
+		}
+		if opts.TeamPermission != "" {
+			logx.Warnf("gitea sets permissions per team, ignoring %s", opts.TeamPermission)
+		}
+		var found struct {
+			Data []struct {
+				ID   int64  `json:"id"`
+				Name string `json:"name"`
+			} `json:"data"`
+		}
+		search := "/orgs/" + url.PathEscape(opts.Org) + "/teams/search?q=" + url.QueryEscape(opts.Team)
+		if err := g.api.do(ctx, http.MethodGet, search, nil, &found); err != nil {
+			return fmt.Errorf("find gitea team %s: %w", opts.Team, err)
+		}
+		var teamID int64
+		for _, t := range found.Data {
+			if t.Name == opts.Team {
+				teamID = t.ID
+			}
+		}
+		if teamID == 0 {
+			return fmt.Errorf("gitea team %s not found in %s", opts.Team, opts.Org)
+		}
+		path := fmt.Sprintf("/teams/%d/repos/%s/%s", teamID, url.PathEscape(repo.Owner), url.PathEscape(repo.Name))
+		if err := g.api.do(ctx, http.MethodPut, path, nil, nil); err != nil {
+			return fmt.Errorf("add repo to gitea team %s: %w", opts.Team, err)
+		}
+	}
+	return nil
+}
+
+func (g *gitea) DeleteRepo(ctx context.Context, repo *Repo) error {
+	if repo == nil {
+		return nil
+	}
+	if err := g.api.do(ctx, http.MethodDelete, g.repoPath(repo), nil, nil); err != nil {
+		return fmt.Errorf("delete gitea repo: %w", err)
+	}
+	return nil
+}
+
+func (g *gitea) GetRepo(ctx context.Context, fullName string) (*Repo, error) {
+	owner, name, ok := strings.Cut(fullName, "/")
+	if !ok {
+		return nil, fmt.Errorf("repository %q must be owner/name", fullName)
+	}
+	var r giteaRepo
+	if err := g.api.do(ctx, http.MethodGet, "/repos/"+url.PathEscape(owner)+"/"+url.PathEscape(name), nil, &r); err != nil {
+		return nil, fmt.Errorf("get gitea repo %s: %w", fullName, err)
+	}
+	return r.repo(), nil
+}
+
+func (g *gitea) OpenPullRequest(ctx context.Context, repo *Repo, pr PullRequest) (string, error) {
+	var out struct {
+		HTMLURL string `json:"html_url"`
+	}
+	err := g.api.do(ctx, http.MethodPost, g.repoPath(repo)+"/pulls", map[string]any{
+		"head":  pr.Head,
+		"base":  pr.Base,
+		"title": pr.Title,
+		"body":  pr.Body,
+	}, &out)
+	if err != nil {
+		return "", fmt.Errorf("open gitea pull request: %w", err)
+	}
+	return out.HTMLURL, nil
+}
+
+// SetSecret creates or updates an Actions secret (Gitea encrypts it server-side).
+func (g *gitea) SetSecret(ctx context.Context, repo *Repo, name, value string) error {
+	path := g.repoPath(repo) + "/actions/secrets/" + url.PathEscape(name)
+	if err := g.api.do(ctx, http.MethodPut, path, map[string]any{"data": value}, nil); err != nil {
+		return fmt.Errorf("set gitea secret %s: %w", name, err)
+	}
+	return nil
+}
+
+// SetVariable creates an Actions variable, updating it when it already exists.
+func (g *gitea) SetVariable(ctx context.Context, repo *Repo, name, value string) error {
+	path := g.repoPath(repo) + "/actions/variables/" + url.PathEscape(name)
+	body := map[string]any{"value": value}
+
+	err := g.api.do(ctx, http.MethodPost, path, body, nil)
+	var apiErr *APIError
+	if errors.As(err, &apiErr) && apiErr.StatusCode == http.StatusConflict {
+		err = g.api.do(ctx, http.MethodPut, path, body, nil)
+	}
+	if err != nil {
+		return fmt.Errorf("set gitea variable %s: %w", name, err)
+	}
+	return nil
+}
+
+func (*gitea) repoPath(repo *Repo) string {
+	return "/repos/" + url.PathEscape(repo.Owner) + "/" + url.PathEscape(repo.Name)
+}
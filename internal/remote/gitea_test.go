@@ -0,0 +1,100 @@
+package remote
+
+import (
+	"context"
+	"net/http"
+	"reflect"
+	"testing"
+)
+
+const giteaCreated = `{
+	"name": "app", "full_name": "acme/app", "owner": {"login": "acme"},
+	"ssh_url": "git@gitea.example.com:acme/app.git", "clone_url": "https://gitea.example.com/acme/app.git"
+}`
+
+func TestGiteaCreateRepoInOrg(t *testing.T) {
+	f := newFakeAPI(t, map[string]func(http.ResponseWriter){
+		"POST /api/v1/orgs/acme/repos":       respond(http.StatusCreated, giteaCreated),
+		"PATCH /api/v1/repos/acme/app":       respond(http.StatusOK, giteaCreated),
+		"PUT /api/v1/repos/acme/app/topics":  respond(http.StatusNoContent, ``),
+		"GET /api/v1/orgs/acme/teams/search": respond(http.StatusOK, `{"data":[{"id":3,"name":"devs-old"},{"id":4,"name":"devs"}]}`),
+		"PUT /api/v1/teams/4/repos/acme/app": respond(http.StatusNoContent, ``),
+	})
+	g := newGitea(f.URL, "test-token")
+
+	repo, err := g.CreateRepo(context.Background(), CreateRepoOptions{
+		Name:          "app",
+		Org:           "acme",
+		Private:       true,
+		Team:          "devs",
+		Topics:        []string{"taco", "go"},
+		Homepage:      "https://acme.dev",
+		DefaultBranch: "trunk",
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if repo.Owner != "acme" || repo.RemoteURL("ssh") != "git@gitea.example.com:acme/app.git" {
+		t.Errorf("repo = %+v", repo)
+	}
+
+	c, _ := f.find(http.MethodPost, "/api/v1/orgs/acme/repos")
+	want := map[string]any{"name": "app", "description": "", "private": true, "default_branch": "trunk"}
+	if !reflect.DeepEqual(c.Body, want) {
+		t.Errorf("create body = %v, want %v", c.Body, want)
+	}
+	if c, _ := f.find(http.MethodPatch, "/api/v1/repos/acme/app"); c.Body["website"] != "https://acme.dev" {
+		t.Errorf("website body = %v", c.Body)
+	}
+	if c, _ := f.find(http.MethodPut, "/api/v1/repos/acme/app/topics"); !reflect.DeepEqual(c.Body["topics"], []any{"taco", "go"}) {
+		t.Errorf("topics body = %v", c.Body)
+	}
+	if c, _ := f.find(http.MethodGet, "/api/v1/orgs/acme/teams/search"); c.Query != "q=devs" {
+		t.Errorf("team search query = %q", c.Query)
+	}
+	if _, ok := f.find(http.MethodPut, "/api/v1/teams/4/repos/acme/app"); !ok {
+		t.Errorf("repo not added to team 4; calls = %v", f.calls)
+	}
+}
+
+func TestGiteaCreateRepoUserAccount(t *testing.T) {
+	f := newFakeAPI(t, map[string]func(http.ResponseWriter){
+		"POST /api/v1/user/repos": respond(http.StatusCreated, giteaCreated),
+	})
+	g := newGitea(f.URL, "test-token")
+
+	if _, err := g.CreateRepo(context.Background(), CreateRepoOptions{Name: "app"}); err != nil {
+		t.Fatal(err)
+	}
+	if n := f.count(); n != 1 {
+		t.Errorf("%d calls, want only the create; calls = %v", n, f.calls)
+	}
+}
+
+func TestGiteaCreateRepoRejectsInternal(t *testing.T) {
+	f := newFakeAPI(t, nil)
+	g := newGitea(f.URL, "test-token")
+
+	if _, err := g.CreateRepo(context.Background(), CreateRepoOptions{Name: "app", Org: "acme", Visibility: "internal"}); err == nil {
+		t.Fatal("expected internal visibility to be rejected")
+	}
+	if n := f.count(); n != 0 {
+		t.Errorf("%d API calls made", n)
+	}
+}
+
+func TestGiteaCreateRepoDeletesOnMissingTeam(t *testing.T) {
+	f := newFakeAPI(t, map[string]func(http.ResponseWriter){
+		"POST /api/v1/orgs/acme/repos":       respond(http.StatusCreated, giteaCreated),
+		"GET /api/v1/orgs/acme/teams/search": respond(http.StatusOK, `{"data":[]}`),
+		"DELETE /api/v1/repos/acme/app":      respond(http.StatusNoContent, ``),
+	})
+	g := newGitea(f.URL, "test-token")
+
+	if _, err := g.CreateRepo(context.Background(), CreateRepoOptions{Name: "app", Org: "acme", Team: "devs"}); err == nil {
+		t.Fatal("expected a missing team to fail")
+	}
+	if _, ok := f.find(http.MethodDelete, "/api/v1/repos/acme/app"); !ok {
+		t.Errorf("half-created repo not deleted; calls = %v", f.calls)
+	}
+}
@@ -0,0 +1,126 @@
+package remote
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/b-jonathan/taco/internal/gh"
+	"github.com/b-jonathan/taco/internal/logx"
+	"github.com/b-jonathan/taco/internal/policy"
+	github "github.com/google/go-github/v55/github"
+)
+
+type githubProvider struct {
+	client *github.Client
+}
+
+func newGitHub(ctx context.Context, baseURL, token string, create bool) (*githubProvider, error) {
+	tok, err := gh.ResolveToken(ctx, token, gh.HostFromBaseURL(baseURL))
+	if err != nil {
+		return nil, err
+	}
+	client := gh.NewClient(ctx, tok.Value)
+	if baseURL != "" {
+		if client, err = client.WithEnterpriseURLs(baseURL, baseURL); err != nil {
+			return nil, fmt.Errorf("github enterprise url: %w", err)
+		}
+	}
+	login, err := gh.ValidateToken(ctx, client, tok, gh.RequiredScopes(create))
+	if err != nil {
+		return nil, err
+	}
+	logx.Infof("Authenticated to GitHub as %s (token from %s)", login, tok.Source)
+	return &githubProvider{client: client}, nil
+}
+
+func (*githubProvider) Name() string { return "github" }
+
+// withClient hands the validated client to the gh helpers.
+func (p *githubProvider) withClient(ctx context.Context) context.Context {
+	return gh.WithContext(ctx, p.client)
+}
+
+func (p *githubProvider) CreateRepo(ctx context.Context, opts CreateRepoOptions) (*Repo, error) {
+	if err := opts.Validate(); err != nil {
+		return nil, err
+	}
+	ctx = p.withClient(ctx)
+	r, err := gh.CreateRepo(ctx, gh.CreateRepoOptions{
+		Name:        opts.Name,
+		Private:     opts.Private,
+		Description: opts.Description,
+
+		Org:            opts.Org,
+		Visibility:     opts.Visibility,
+		Team:           opts.Team,
+		TeamPermission: opts.TeamPermission,
+		Topics:         opts.Topics,
+		Homepage:       opts.Homepage,
+	})
+	if err != nil {
+		return nil, err
+	}
+	// GitHub makes the first pushed branch the default, so DefaultBranch is
+	// honoured by pushing it.
+	return githubRepo(r), nil
+}
+
+func githubRepo(r *github.Repository) *Repo {
+	return &Repo{
+		Owner:         r.GetOwner().GetLogin(),
+		Name:          r.GetName(),
+		FullName:      r.GetFullName(),
+		HTMLURL:       r.GetHTMLURL(),
+		SSHURL:        r.GetSSHURL(),
+		CloneURL:      r.GetCloneURL(),
+		DefaultBranch: r.GetDefaultBranch(),
+	}
+}
+
+func (p *githubProvider) GetRepo(ctx context.Context, fullName string) (*Repo, error) {
+	owner, name, ok := strings.Cut(fullName, "/")
+	if !ok {
+		return nil, fmt.Errorf("repository %q must be owner/name", fullName)
+	}
+	r, err := gh.GetRepo(p.withClient(ctx), owner, name)
+	if err != nil {
+		return nil, err
+	}
+	return githubRepo(r), nil
+}
+
+func (p *githubProvider) OpenPullRequest(ctx context.Context, repo *Repo, pr PullRequest) (string, error) {
+	r, err := gh.CreatePullRequest(p.withClient(ctx), repo.Owner, repo.Name, pr.Head, pr.Base, pr.Title, pr.Body)
+	if err != nil {
+		return "", err
+	}
+	return r.GetHTMLURL(), nil
+}
+
+func (p *githubProvider) DeleteRepo(ctx context.Context, repo *Repo) error {
+	if repo == nil {
+		return nil
+	}
+	ctx = p.withClient(ctx)
+	return gh.DeleteRepo(ctx, &github.Repository{
+		Name:     github.String(repo.Name),
+		FullName: github.String(repo.FullName),
+		Owner:    &github.User{Login: github.String(repo.Owner)},
+	})
+}
+
+func (p *githubProvider) SetSecret(ctx context.Context, repo *Repo, name, value string) error {
+	ctx = p.withClient(ctx)
+	return gh.SetSecret(ctx, repo.Owner, repo.Name, name, value)
+}
+
+func (p *githubProvider) SetVariable(ctx context.Context, repo *Repo, name, value string) error {
+	ctx = p.withClient(ctx)
+	return gh.SetVariable(ctx, repo.Owner, repo.Name, name, value)
+}
+
+func (p *githubProvider) ApplyPolicy(ctx context.Context, repo *Repo, pol policy.Policy) error {
+	ctx = p.withClient(ctx)
+	return gh.ApplyPolicy(ctx, repo.Owner, repo.Name, pol)
+}
@@ -0,0 +1,83 @@
+package remote
+
+import (
+	"context"
+	"net/http"
+	"net/url"
+	"testing"
+
+	"github.com/b-jonathan/taco/internal/gh"
+)
+
+func newFakeGitHubProvider(t *testing.T, routes map[string]func(http.ResponseWriter)) (*githubProvider, *fakeAPI) {
+	t.Helper()
+	f := newFakeAPI(t, routes)
+	client := gh.NewClient(context.Background(), "test-token")
+	client.BaseURL, _ = url.Parse(f.URL + "/")
+	return &githubProvider{client: client}, f
+}
+
+func TestGitHubCreateRepoInOrg(t *testing.T) {
+	p, f := newFakeGitHubProvider(t, map[string]func(http.ResponseWriter){
+		"POST /orgs/acme/repos": respond(http.StatusCreated, `{
+			"name": "app", "full_name": "acme/app", "owner": {"login": "acme"},
+			"ssh_url": "git@github.com:acme/app.git", "clone_url": "https://github.com/acme/app.git"
+		}`),
+		"PUT /repos/acme/app/topics":               respond(http.StatusOK, `{"names":["taco"]}`),
+		"PUT /orgs/acme/teams/devs/repos/acme/app": respond(http.StatusNoContent, ``),
+	})
+
+	repo, err := p.CreateRepo(context.Background(), CreateRepoOptions{
+		Name:           "app",
+		Org:            "acme",
+		Visibility:     "private",
+		Team:           "devs",
+		TeamPermission: "triage",
+		Topics:         []string{"taco"},
+		Homepage:       "https://acme.dev",
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if repo.Owner != "acme" || repo.FullName != "acme/app" || repo.RemoteURL("ssh") != "git@github.com:acme/app.git" {
+		t.Errorf("repo = %+v", repo)
+	}
+
+	c, ok := f.find(http.MethodPost, "/orgs/acme/repos")
+	if !ok {
+		t.Fatalf("no POST /orgs/acme/repos; calls = %v", f.calls)
+	}
+	if c.Body["visibility"] != "private" || c.Body["homepage"] != "https://acme.dev" {
+		t.Errorf("create body = %v", c.Body)
+	}
+	if c, _ := f.find(http.MethodPut, "/orgs/acme/teams/devs/repos/acme/app"); c.Body["permission"] != "triage" {
+		t.Errorf("team body = %v", c.Body)
+	}
+	if _, ok := f.find(http.MethodPut, "/repos/acme/app/topics"); !ok {
+		t.Error("topics not set")
+	}
+}
+
+func TestGitHubCreateRepoRejectsBadOptions(t *testing.T) {
+	p, f := newFakeGitHubProvider(t, nil)
+	_, err := p.CreateRepo(context.Background(), CreateRepoOptions{Name: "app", Team: "devs"})
+	if err == nil {
+		t.Fatal("expected --team without --org to fail")
+	}
+	if n := f.count(); n != 0 {
+		t.Errorf("%d API calls made for invalid options", n)
+	}
+}
+
+func TestGitHubDeleteRepoUsesOwner(t *testing.T) {
+	p, f := newFakeGitHubProvider(t, map[string]func(http.ResponseWriter){
+		"DELETE /repos/acme/app": respond(http.StatusNoContent, ``),
+	})
+	err := p.DeleteRepo(context.Background(), &Repo{Owner: "acme", Name: "app", FullName: "acme/app"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, ok := f.find(http.MethodDelete, "/repos/acme/app"); !ok {
+		t.Errorf("calls = %v", f.calls)
+	}
+}
@@ -0,0 +1,186 @@
+package remote
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+
+	"github.com/b-jonathan/taco/internal/logx"
+)
+
+type gitlab struct {
+	api *apiClient
+}
+
+func newGitLab(baseURL, token string) *gitlab {
+	h := http.Header{}
+	h.Set("PRIVATE-TOKEN", token)
+	return &gitlab{api: newAPIClient(baseURL+"/api/v4", h)}
+}
+
+func (*gitlab) Name() string { return "gitlab" }
+
+type gitlabProject struct {
+	Path              string `json:"path"`
+	PathWithNamespace string `json:"path_with_namespace"`
+	WebURL            string `json:"web_url"`
+	SSHURLToRepo      string `json:"ssh_url_to_repo"`
+	HTTPURLToRepo     string `json:"http_url_to_repo"`
+	DefaultBranch     string `json:"default_branch"`
+	Namespace         struct {
+		FullPath string `json:"full_path"`
+	} `json:"namespace"`
+}
+
+func (p gitlabProject) repo() *Repo {
+	return &Repo{
+		Owner:         p.Namespace.FullPath,
+		Name:          p.Path,
+		FullName:      p.PathWithNamespace,
+		HTMLURL:       p.WebURL,
+		SSHURL:        p.SSHURLToRepo,
+		CloneURL:      p.HTTPURLToRepo,
+		DefaultBranch: p.DefaultBranch,
+	}
+}
+
+// gitlabAccess maps GitHub-style permissions onto GitLab access levels.
+var gitlabAccess = map[string]int{"pull": 20, "triage": 20, "push": 30, "maintain": 40, "admin": 50}
+
+func (g *gitlab) CreateRepo(ctx context.Context, opts CreateRepoOptions) (*Repo, error) {
+	body := map[string]any{
+		"name":        opts.Name,
+		"path":        opts.Name,
+		"description": opts.Description,
+		"visibility":  opts.visibility(),
+	}
+	if len(opts.Topics) > 0 {
+		body["topics"] = opts.Topics
+	}
+	if opts.DefaultBranch != "" {
+		body["default_branch"] = opts.DefaultBranch
+	}
+	if opts.Homepage != "" {
+		logx.Warnf("gitlab projects have no homepage setting, ignoring %s", opts.Homepage)
+	}
+	if opts.Org != "" {
+		id, err := g.lookupID(ctx, "/namespaces/", opts.Org)
+		if err != nil {
+			return nil, fmt.Errorf("find gitlab namespace %s: %w", opts.Org, err)
+		}
+		body["namespace_id"] = id
+	}
+
+	var p gitlabProject
+	if err := g.api.do(ctx, http.MethodPost, "/projects", body, &p); err != nil {
+		return nil, fmt.Errorf("create gitlab project: %w", err)
+	}
+	repo := p.repo()
+
+	// GitLab "teams" are groups the project is shared with
+	if opts.Team != "" {
+		if err := g.share(ctx, repo, opts.Team, opts.TeamPermission); err != nil {
+			if derr := g.DeleteRepo(ctx, repo); derr != nil {
+				err = fmt.Errorf("%w (cleanup: %v)", err, derr)
+			}
+			return nil, err
+		}
+	}
+	return repo, nil
+}
+
+func (g *gitlab) share(ctx context.Context, repo *Repo, group, permission string) error {
+	if permission == "" {
+		permission = "push"
+	}
+	access, ok := gitlabAccess[permission]
+	if !ok {
+		return fmt.Errorf("unknown team permission %q", permission)
+	}
+	id, err := g.lookupID(ctx, "/groups/", group)
+	if err != nil {
+		return fmt.Errorf("find gitlab group %s: %w", group, err)
+	}
+	err = g.api.do(ctx, http.MethodPost, "/projects/"+url.PathEscape(repo.FullName)+"/share", map[string]any{
+		"group_id":     id,
+		"group_access": access,
+	}, nil)
+	if err != nil {
+		return fmt.Errorf("share with gitlab group %s: %w", group, err)
+	}
+	return nil
+}
+
+func (g *gitlab) lookupID(ctx context.Context, prefix, path string) (int, error) {
+	var out struct {
+		ID int `json:"id"`
+	}
+	if err := g.api.do(ctx, http.MethodGet, prefix+url.PathEscape(path), nil, &out); err != nil {
+		return 0, err
+	}
+	return out.ID, nil
+}
+
+func (g *gitlab) DeleteRepo(ctx context.Context, repo *Repo) error {
+	if repo == nil {
+		return nil
+	}
+	if err := g.api.do(ctx, http.MethodDelete, "/projects/"+url.PathEscape(repo.FullName), nil, nil); err != nil {
+		return fmt.Errorf("delete gitlab project: %w", err)
+	}
+	return nil
+}
+
+func (g *gitlab) GetRepo(ctx context.Context, fullName string) (*Repo, error) {
+	var p gitlabProject
+	if err := g.api.do(ctx, http.MethodGet, "/projects/"+url.PathEscape(fullName), nil, &p); err != nil {
+		return nil, fmt.Errorf("get gitlab project %s: %w", fullName, err)
+	}
+	return p.repo(), nil
+}
+
+// OpenPullRequest opens a merge request.
+func (g *gitlab) OpenPullRequest(ctx context.Context, repo *Repo, pr PullRequest) (string, error) {
+	var out struct {
+		WebURL string `json:"web_url"`
+	}
+	err := g.api.do(ctx, http.MethodPost, "/projects/"+url.PathEscape(repo.FullName)+"/merge_requests", map[string]any{
+		"source_branch":        pr.Head,
+		"target_branch":        pr.Base,
+		"title":                pr.Title,
+		"description":          pr.Body,
+		"remove_source_branch": true,
+	}, &out)
+	if err != nil {
+		return "", fmt.Errorf("open gitlab merge request: %w", err)
+	}
+	return out.WebURL, nil
+}
+
+// SetSecret stores a masked CI/CD variable.
+func (g *gitlab) SetSecret(ctx context.Context, repo *Repo, name, value string) error {
+	return g.setVariable(ctx, repo, name, value, true)
+}
+
+// SetVariable stores a plain CI/CD variable.
+func (g *gitlab) SetVariable(ctx context.Context, repo *Repo, name, value string) error {
+	return g.setVariable(ctx, repo, name, value, false)
+}
+
+// setVariable creates the variable, updating it when it already exists.
+func (g *gitlab) setVariable(ctx context.Context, repo *Repo, name, value string, masked bool) error {
+	base := "/projects/" + url.PathEscape(repo.FullName) + "/variables"
+	body := map[string]any{"key": name, "value": value, "masked": masked}
+
+	err := g.api.do(ctx, http.MethodPost, base, body, nil)
+	var apiErr *APIError
+	if errors.As(err, &apiErr) && apiErr.StatusCode == http.StatusBadRequest {
+		err = g.api.do(ctx, http.MethodPut, base+"/"+url.PathEscape(name), body, nil)
+	}
+	if err != nil {
+		return fmt.Errorf("set gitlab variable %s: %w", name, err)
+	}
+	return nil
+}
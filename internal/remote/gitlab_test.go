@@ -0,0 +1,90 @@
+package remote
+
+import (
+	"context"
+	"net/http"
+	"reflect"
+	"testing"
+)
+
+const gitlabCreated = `{
+	"path": "app", "path_with_namespace": "acme/app", "namespace": {"full_path": "acme"},
+	"ssh_url_to_repo": "git@gitlab.example.com:acme/app.git", "http_url_to_repo": "https://gitlab.example.com/acme/app.git"
+}`
+
+func TestGitLabCreateRepoInGroup(t *testing.T) {
+	f := newFakeAPI(t, map[string]func(http.ResponseWriter){
+		"GET /api/v4/namespaces/acme":            respond(http.StatusOK, `{"id": 7}`),
+		"POST /api/v4/projects":                  respond(http.StatusCreated, gitlabCreated),
+		"GET /api/v4/groups/devs":                respond(http.StatusOK, `{"id": 9}`),
+		"POST /api/v4/projects/acme%2Fapp/share": respond(http.StatusCreated, `{}`),
+	})
+	g := newGitLab(f.URL, "test-token")
+
+	repo, err := g.CreateRepo(context.Background(), CreateRepoOptions{
+		Name:           "app",
+		Org:            "acme",
+		Visibility:     "internal",
+		Team:           "devs",
+		TeamPermission: "maintain",
+		Topics:         []string{"taco"},
+		DefaultBranch:  "trunk",
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if repo.FullName != "acme/app" || repo.RemoteURL("https") != "https://gitlab.example.com/acme/app.git" {
+		t.Errorf("repo = %+v", repo)
+	}
+
+	c, _ := f.find(http.MethodPost, "/api/v4/projects")
+	want := map[string]any{
+		"name": "app", "path": "app", "description": "", "visibility": "internal",
+		"namespace_id": float64(7), "topics": []any{"taco"}, "default_branch": "trunk",
+	}
+	if !reflect.DeepEqual(c.Body, want) {
+		t.Errorf("create body = %v, want %v", c.Body, want)
+	}
+	c, ok := f.find(http.MethodPost, "/api/v4/projects/acme%2Fapp/share")
+	if !ok {
+		t.Fatalf("project not shared; calls = %v", f.calls)
+	}
+	if c.Body["group_id"] != float64(9) || c.Body["group_access"] != float64(40) {
+		t.Errorf("share body = %v", c.Body)
+	}
+}
+
+func TestGitLabCreateRepoDeletesOnShareFailure(t *testing.T) {
+	f := newFakeAPI(t, map[string]func(http.ResponseWriter){
+		"POST /api/v4/projects":              respond(http.StatusCreated, gitlabCreated),
+		"DELETE /api/v4/projects/acme%2Fapp": respond(http.StatusAccepted, `{}`),
+		// the group lookup is unrouted and fails with a 404
+	})
+	g := newGitLab(f.URL, "test-token")
+
+	if _, err := g.CreateRepo(context.Background(), CreateRepoOptions{Name: "app", Team: "missing"}); err == nil {
+		t.Fatal("expected sharing with a missing group to fail")
+	}
+	if _, ok := f.find(http.MethodDelete, "/api/v4/projects/acme%2Fapp"); !ok {
+		t.Errorf("half-created project not deleted; calls = %v", f.calls)
+	}
+}
+
+func TestGitLabSetVariableUpdatesExisting(t *testing.T) {
+	f := newFakeAPI(t, map[string]func(http.ResponseWriter){
+		"POST /api/v4/projects/acme%2Fapp/variables":        respond(http.StatusBadRequest, `{"message":{"key":["has already been taken"]}}`),
+		"PUT /api/v4/projects/acme%2Fapp/variables/API_KEY": respond(http.StatusOK, `{}`),
+	})
+	g := newGitLab(f.URL, "test-token")
+
+	if err := g.SetSecret(context.Background(), &Repo{FullName: "acme/app"}, "API_KEY", "s3cret"); err != nil {
+		t.Fatal(err)
+	}
+	c, ok := f.find(http.MethodPut, "/api/v4/projects/acme%2Fapp/variables/API_KEY")
+	if !ok {
+		t.Fatalf("existing variable not updated; calls = %v", f.calls)
+	}
+	if c.Body["value"] != "s3cret" || c.Body["masked"] != true {
+		t.Errorf("update body = %v", c.Body)
+	}
+}
@@ -0,0 +1,49 @@
+package remote
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"strings"
+)
+
+// Hosts lists the supported values for --host.
+var Hosts = []string{"github", "gitlab", "gitea"}
+
+// New returns the provider for host, authenticated with token or the host's
+// usual credential sources. baseURL points at a self-hosted instance (GitHub
+// Enterprise, self-managed GitLab, Gitea); empty means the public service.
+// GitHub credentials are validated here so a bad token fails before scaffolding;
+// create says whether init will create (and possibly delete) the repository,
+// which needs more scopes than pushing to an existing one.
+func New(ctx context.Context, host, baseURL, token string, create bool) (Provider, error) {
+	baseURL = strings.TrimRight(baseURL, "/")
+	switch strings.ToLower(host) {
+	case "", "github":
+		return newGitHub(ctx, baseURL, token, create)
+	case "gitlab":
+		if baseURL == "" {
+			baseURL = "https://gitlab.com"
+		}
+		if token == "" {
+			token = os.Getenv("GITLAB_TOKEN")
+		}
+		if token == "" {
+			return nil, fmt.Errorf("pass --token or set GITLAB_TOKEN to use gitlab")
+		}
+		return newGitLab(baseURL, token), nil
+	case "gitea":
+		if baseURL == "" {
+			return nil, fmt.Errorf("gitea requires --base-url")
+		}
+		if token == "" {
+			token = os.Getenv("GITEA_TOKEN")
+		}
+		if token == "" {
+			return nil, fmt.Errorf("pass --token or set GITEA_TOKEN to use gitea")
+		}
+		return newGitea(baseURL, token), nil
+	default:
+		return nil, fmt.Errorf("unknown host %q. available: %v", host, Hosts)
+	}
+}
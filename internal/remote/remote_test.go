@@ -0,0 +1,38 @@
+package remote
+
+import (
+	"context"
+	"testing"
+)
+
+func TestNewSelectsProviderByHost(t *testing.T) {
+	t.Setenv("GITLAB_TOKEN", "")
+	t.Setenv("GITEA_TOKEN", "")
+	ctx := context.Background()
+
+	p, err := New(ctx, "GitLab", "https://gitlab.example.com/", "tok", true)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if g, ok := p.(*gitlab); !ok || g.api.baseURL != "https://gitlab.example.com/api/v4" {
+		t.Errorf("GitLab host = %T %+v", p, p)
+	}
+
+	p, err = New(ctx, "gitea", "https://gitea.example.com", "tok", true)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if g, ok := p.(*gitea); !ok || g.api.baseURL != "https://gitea.example.com/api/v1" {
+		t.Errorf("gitea host = %T %+v", p, p)
+	}
+
+	if _, err := New(ctx, "gitea", "", "tok", true); err == nil {
+		t.Error("gitea without a base URL accepted")
+	}
+	if _, err := New(ctx, "gitlab", "", "", true); err == nil {
+		t.Error("gitlab without a token accepted")
+	}
+	if _, err := New(ctx, "bitbucket", "", "tok", true); err == nil {
+		t.Error("unknown host accepted")
+	}
+}
@@ -0,0 +1,98 @@
+package remote
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/b-jonathan/taco/internal/policy"
+)
+
+// Provider is a git hosting service taco can create repositories on.
+type Provider interface {
+	Name() string
+	CreateRepo(ctx context.Context, opts CreateRepoOptions) (*Repo, error)
+	DeleteRepo(ctx context.Context, repo *Repo) error
+	// GetRepo looks up an existing repository by "owner/name".
+	GetRepo(ctx context.Context, fullName string) (*Repo, error)
+	// OpenPullRequest opens a pull (merge) request from head into base and returns its URL.
+	OpenPullRequest(ctx context.Context, repo *Repo, pr PullRequest) (string, error)
+	SetSecret(ctx context.Context, repo *Repo, name, value string) error
+	SetVariable(ctx context.Context, repo *Repo, name, value string) error
+}
+
+// PolicyApplier is implemented by providers that can apply branch protection,
+// merge strategies and labels after the initial push.
+type PolicyApplier interface {
+	ApplyPolicy(ctx context.Context, repo *Repo, p policy.Policy) error
+}
+
+type CreateRepoOptions struct {
+	Name        string
+	Description string
+	Private     bool
+
+	Org            string // organization / group / namespace; empty for the user account
+	Visibility     string // "public", "private" or "internal"; overrides Private
+	Team           string
+	TeamPermission string
+	Topics         []string
+	Homepage       string
+	DefaultBranch  string
+}
+
+// Validate catches option combinations no provider accepts.
+func (o CreateRepoOptions) Validate() error {
+	switch o.Visibility {
+	case "", "public", "private", "internal":
+	default:
+		return fmt.Errorf("unknown visibility %q (allowed: public, private, internal)", o.Visibility)
+	}
+	if o.Visibility == "internal" && o.Org == "" {
+		return fmt.Errorf("internal visibility requires --org")
+	}
+	if o.Team != "" && o.Org == "" {
+		return fmt.Errorf("--team requires --org")
+	}
+	switch o.TeamPermission {
+	case "", "pull", "triage", "push", "maintain", "admin":
+	default:
+		return fmt.Errorf("unknown team permission %q (allowed: pull, triage, push, maintain, admin)", o.TeamPermission)
+	}
+	return nil
+}
+
+func (o CreateRepoOptions) visibility() string {
+	if o.Visibility != "" {
+		return o.Visibility
+	}
+	if o.Private {
+		return "private"
+	}
+	return "public"
+}
+
+type PullRequest struct {
+	Head  string // branch with the changes
+	Base  string // branch to merge into
+	Title string
+	Body  string
+}
+
+// Repo is the provider-neutral view of a created repository.
+type Repo struct {
+	Owner         string
+	Name          string
+	FullName      string // owner/name (or group/subgroup/name on GitLab)
+	HTMLURL       string
+	SSHURL        string
+	CloneURL      string // https
+	DefaultBranch string
+}
+
+// RemoteURL returns the clone URL for the given remote type ("ssh" or "https").
+func (r *Repo) RemoteURL(kind string) string {
+	if kind == "https" {
+		return r.CloneURL
+	}
+	return r.SSHURL
+}
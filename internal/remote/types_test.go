@@ -0,0 +1,35 @@
+package remote
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestCreateRepoOptionsValidate(t *testing.T) {
+	tests := []struct {
+		name    string
+		opts    CreateRepoOptions
+		wantErr string
+	}{
+		{"user account", CreateRepoOptions{Name: "app", Private: true}, ""},
+		{"org with team", CreateRepoOptions{Name: "app", Org: "acme", Visibility: "internal", Team: "devs", TeamPermission: "admin"}, ""},
+		{"unknown visibility", CreateRepoOptions{Name: "app", Visibility: "secret"}, "unknown visibility"},
+		{"internal without org", CreateRepoOptions{Name: "app", Visibility: "internal"}, "requires --org"},
+		{"team without org", CreateRepoOptions{Name: "app", Team: "devs"}, "requires --org"},
+		{"unknown permission", CreateRepoOptions{Name: "app", Org: "acme", Team: "devs", TeamPermission: "write"}, "unknown team permission"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := tt.opts.Validate()
+			if tt.wantErr == "" {
+				if err != nil {
+					t.Fatalf("unexpected error: %v", err)
+				}
+				return
+			}
+			if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+				t.Fatalf("error = %v, want %q", err, tt.wantErr)
+			}
+		})
+	}
+}
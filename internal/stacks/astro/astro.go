@@ -0,0 +1,156 @@
+package astro
+
+import (
+	"context"
+	"fmt"
+	"path/filepath"
+	"strings"
+
+	"github.com/b-jonathan/taco/internal/execx"
+	"github.com/b-jonathan/taco/internal/fsutil"
+	"github.com/b-jonathan/taco/internal/nodepkg"
+	"github.com/b-jonathan/taco/internal/prompt"
+	"github.com/b-jonathan/taco/internal/stacks"
+	"github.com/spf13/afero"
+)
+
+type Stack = stacks.Stack
+type Options = stacks.Options
+
+type astro struct {
+	tailwind bool
+}
+
+func New() Stack { return &astro{} }
+
+func (*astro) Type() string { return "frontend" }
+
+func (*astro) Name() string { return "astro" }
+
+// templateData is passed to the astro templates.
+type templateData struct {
+	Tailwind bool
+}
+
+func (a *astro) Init(ctx context.Context, opts *Options) error {
+	if prompt.IsTTY() {
+		b, err := prompt.CreateSurveyConfirm("Add Tailwind CSS to the Astro site?", prompt.AskOpts{
+			Default: false,
+		})
+		if err != nil {
+			return err
+		}
+		a.tailwind = b
+	}
+
+	if err := fsutil.Fs.MkdirAll(opts.ProjectRoot, 0o755); err != nil {
+		return fmt.Errorf("mkdir: %w", err)
+	}
+	// every question create-astro would ask is answered by a flag; pinned like create-vite
+	astroFlags := []string{
+		"--yes",
+		"create-astro@4.13.1",
+		"frontend",
+		"--template", "minimal",
+		"--install",
+		"--no-git",
+		"--skip-houston",
+		"--yes",
+	}
+	if err := execx.RunCmd(ctx, opts.ProjectRoot, "npx "+strings.Join(astroFlags, " ")); err != nil {
+		return fmt.Errorf("create-astro: %w", err)
+	}
+
+	frontendDir := filepath.Join(opts.ProjectRoot, "frontend")
+	if a.tailwind {
+		if err := execx.RunCmd(ctx, frontendDir, "npm install tailwindcss @tailwindcss/vite"); err != nil {
+			return fmt.Errorf("npm install tailwind: %w", err)
+		}
+	}
+
+	frontendDeps := []string{
+		"eslint",
+		"@eslint/js",
+		"globals",
+		"typescript-eslint",
+		"eslint-plugin-astro",
+		"eslint-config-prettier",
+		"prettier",
+		"prettier-plugin-astro",
+	}
+	if a.tailwind {
+		frontendDeps = append(frontendDeps, "prettier-plugin-tailwindcss")
+	}
+	if err := execx.RunCmd(ctx, frontendDir, "npm install -D "+strings.Join(frontendDeps, " ")); err != nil {
+		return fmt.Errorf("npm install dev deps: %w", err)
+	}
+	return nil
+}
+
+func (a *astro) Generate(ctx context.Context, opts *Options) error {
+	frontendDir := filepath.Join(opts.ProjectRoot, "frontend")
+	data := templateData{Tailwind: a.tailwind}
+
+	if err := fsutil.GenerateFromTemplateDirData("astro/base", frontendDir, data); err != nil {
+		return fmt.Errorf("generate astro templates: %w", err)
+	}
+	if a.tailwind {
+		if err := fsutil.GenerateFromTemplateDirData("astro/tailwind", frontendDir, data); err != nil {
+			return fmt.Errorf("generate astro tailwind templates: %w", err)
+		}
+	}
+
+	packageParams := nodepkg.InitPackageParams{
+		Name: "frontend",
+		Scripts: map[string]string{
+			"lint-check": "eslint . && prettier --check .",
+			"lint-fix":   "(eslint . --fix || true) && prettier --write .",
+		}}
+
+	if err := nodepkg.InitPackage(frontendDir, packageParams); err != nil {
+		return fmt.Errorf("init astro package.json: %w", err)
+	}
+
+	return nil
+}
+
+func (*astro) Post(ctx context.Context, opts *Options) error {
+	envPath := filepath.Join(opts.ProjectRoot, "frontend", ".env")
+	if err := fsutil.EnsureFile(envPath); err != nil {
+		return fmt.Errorf("ensure .env: %w", err)
+	}
+	content := "PUBLIC_BACKEND_URL=" + opts.BackendURL + "\n"
+	if err := afero.WriteFile(fsutil.Fs, envPath, []byte(content), 0o644); err != nil {
+		return fmt.Errorf("write %s: %w", envPath, err)
+	}
+	return nil
+}
+
+// Excludes a client runtime: the site is built to static HTML, so auth stacks
+// that keep a session in browser JavaScript have nowhere to run.
+func (*astro) Excludes() []string {
+	return []string{stacks.ClientRuntime}
+}
+
+func (*astro) GitIgnore(opts *Options) []string {
+	return []string{"frontend/node_modules/", "frontend/dist/", "frontend/.astro/", "frontend/.env", "frontend/.env.*"}
+}
+
+func (*astro) Services(ctx context.Context, opts *Options) ([]stacks.Service, error) {
+	return []stacks.Service{{
+		Name: "frontend",
+		Dir:  filepath.Join(opts.ProjectRoot, "frontend"),
+		Cmd:  "npm run dev",
+		Port: 3000,
+	}}, nil
+}
+
+func (*astro) Rollback(ctx context.Context, opts *Options) error {
+	frontendDir := filepath.Join(opts.ProjectRoot, "frontend")
+
+	if err := fsutil.RemoveDir(frontendDir); err != nil {
+		return fmt.Errorf("remove frontend dir: %w", err)
+	}
+
+	return nil
+}
@@ -0,0 +1,80 @@
+package stacks
+
+import (
+	"fmt"
+	"slices"
+)
+
+// Capabilities a stack can need from the stacks it is combined with.
+const (
+	// ClientRuntime is JavaScript running in the browser, e.g. for a
+	// client-side auth SDK that keeps the session.
+	ClientRuntime = "client-runtime"
+
+	// FirebaseUI is a frontend shipping the Firebase sign-in pages
+	// (templates/firebase/<frontend>).
+	FirebaseUI = "firebase-ui"
+
+	// ContainerImage is a Dockerfile for the stack (templates/docker/<stack>),
+	// so it can run in docker compose.
+	ContainerImage = "container-image"
+)
+
+// provided are the capabilities a selected stack has to offer (see Provider);
+// the others are assumed unless a stack excludes them.
+var provided = map[string]bool{FirebaseUI: true}
+
+// Requirer is implemented by stacks that need capabilities from the others.
+type Requirer interface {
+	Requires() []string
+}
+
+// Excluder is implemented by stacks that can't offer some capabilities, so
+// they can't be combined with stacks requiring them.
+type Excluder interface {
+	Excludes() []string
+}
+
+// Provider is implemented by stacks that offer capabilities others can't do
+// without, such as templates for a companion stack.
+type Provider interface {
+	Provides() []string
+}
+
+// CheckCompatible rejects selections where one stack excludes a capability
+// another requires, or where nothing provides a required capability that has
+// to be provided. Nil stacks (the "none" choice) are skipped.
+func CheckCompatible(selected ...Stack) error {
+	for _, s := range selected {
+		r, ok := s.(Requirer)
+		if !ok {
+			continue
+		}
+		for _, o := range selected {
+			e, ok := o.(Excluder)
+			if !ok {
+				continue
+			}
+			for _, c := range r.Requires() {
+				if slices.Contains(e.Excludes(), c) {
+					return fmt.Errorf("%s cannot be used with %s %s: %s needs %s", s.Name(), o.Type(), o.Name(), s.Name(), c)
+				}
+			}
+		}
+		for _, c := range r.Requires() {
+			if provided[c] && !providedBy(c, selected) {
+				return fmt.Errorf("%s needs %s, which none of the selected stacks provide", s.Name(), c)
+			}
+		}
+	}
+	return nil
+}
+
+func providedBy(c string, selected []Stack) bool {
+	for _, s := range selected {
+		if p, ok := s.(Provider); ok && slices.Contains(p.Provides(), c) {
+			return true
+		}
+	}
+	return false
+}
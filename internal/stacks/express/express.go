@@ -77,14 +77,11 @@ func (express) Generate(ctx context.Context, opts *Options) error {
 	return nil
 }
 
-func (express) Post(ctx context.Context, opts *Options) error {
-	gitignorePath := filepath.Join(opts.ProjectRoot, ".gitignore")
-	if err := fsutil.EnsureFile(gitignorePath); err != nil {
-		return fmt.Errorf("ensure gitignore file: %w", err)
-	}
+func (express) GitIgnore(opts *Options) []string {
+	return []string{"backend/node_modules/", "backend/dist/", "backend/.env*"}
+}
 
-	_ = fsutil.AppendUniqueLines(gitignorePath,
-		[]string{"backend/node_modules/", "backend/dist/", "backend/.env*"})
+func (express) Post(ctx context.Context, opts *Options) error {
 	path := filepath.Join(opts.ProjectRoot, "backend", ".env")
 	dir := filepath.Dir(path)
 	if err := fsutil.Fs.MkdirAll(dir, 0o755); err != nil {
@@ -116,6 +113,15 @@ FRONTEND_ORIGIN=http://localhost:3000`
 	return nil
 }
 
+func (express) Services(ctx context.Context, opts *Options) ([]stacks.Service, error) {
+	return []stacks.Service{{
+		Name: "backend",
+		Dir:  filepath.Join(opts.ProjectRoot, "backend"),
+		Cmd:  "npm run dev",
+		Port: opts.Port,
+	}}, nil
+}
+
 func (express) Rollback(ctx context.Context, opts *Options) error {
 	backendDir := filepath.Join(opts.ProjectRoot, "backend")
 
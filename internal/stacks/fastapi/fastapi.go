@@ -0,0 +1,116 @@
+package fastapi
+
+import (
+	"context"
+	"fmt"
+	"os/exec"
+	"path/filepath"
+
+	"github.com/b-jonathan/taco/internal/fsutil"
+	"github.com/b-jonathan/taco/internal/pypkg"
+	"github.com/b-jonathan/taco/internal/stacks"
+	"github.com/spf13/afero"
+)
+
+type Stack = stacks.Stack
+type Options = stacks.Options
+
+type fastapi struct{}
+
+func New() Stack { return &fastapi{} }
+
+func (fastapi) Type() string { return "backend" }
+func (fastapi) Name() string { return "fastapi" }
+
+// templateData is passed to the fastapi templates.
+type templateData struct {
+	AppName string
+}
+
+var (
+	deps    = []string{"fastapi>=0.115", "uvicorn[standard]>=0.30", "python-dotenv>=1.0"}
+	devDeps = []string{"ruff>=0.6", "black>=24.8", "pytest>=8.3", "httpx>=0.27"}
+)
+
+func (fastapi) Init(ctx context.Context, opts *Options) error {
+	if !pypkg.HasUV() {
+		if _, err := exec.LookPath("python3"); err != nil {
+			return fmt.Errorf("neither uv nor python3 found on PATH; install uv from https://docs.astral.sh/uv/")
+		}
+	}
+
+	backendDir := filepath.Join(opts.ProjectRoot, "backend")
+	if err := fsutil.Fs.MkdirAll(backendDir, 0o755); err != nil {
+		return fmt.Errorf("mkdir: %w", err)
+	}
+
+	content, err := fsutil.RenderTemplateData("fastapi/pyproject.toml.tmpl", templateData{AppName: opts.AppName})
+	if err != nil {
+		return fmt.Errorf("render pyproject.toml: %w", err)
+	}
+	if err := fsutil.WriteFile(fsutil.FileInfo{Path: filepath.Join(backendDir, "pyproject.toml"), Content: content}); err != nil {
+		return err
+	}
+
+	if err := pypkg.CreateVenv(ctx, backendDir); err != nil {
+		return err
+	}
+	if err := pypkg.Add(ctx, backendDir, deps...); err != nil {
+		return err
+	}
+	if err := pypkg.AddDev(ctx, backendDir, devDeps...); err != nil {
+		return err
+	}
+	return nil
+}
+
+func (fastapi) Generate(ctx context.Context, opts *Options) error {
+	backendDir := filepath.Join(opts.ProjectRoot, "backend")
+
+	if err := fsutil.GenerateFromTemplateDirData("fastapi/base", backendDir, templateData{AppName: opts.AppName}); err != nil {
+		return fmt.Errorf("generate fastapi templates: %w", err)
+	}
+	return nil
+}
+
+func (fastapi) GitIgnore(opts *Options) []string {
+	return []string{
+		"backend/" + pypkg.VenvDir + "/",
+		"backend/.env*",
+		"backend/**/__pycache__/",
+		"backend/.pytest_cache/",
+		"backend/.ruff_cache/",
+	}
+}
+
+func (fastapi) Post(ctx context.Context, opts *Options) error {
+	path := filepath.Join(opts.ProjectRoot, "backend", ".env")
+	// loaded by python-dotenv in app/__main__.py and app/main.py
+	content := fmt.Sprintf("PORT=%d\nFRONTEND_ORIGIN=%s\n", opts.Port, opts.FrontendURL)
+	if err := afero.WriteFile(fsutil.Fs, path, []byte(content), 0o644); err != nil {
+		return fmt.Errorf("write %s: %w", path, err)
+	}
+	return nil
+}
+
+func (fastapi) Services(ctx context.Context, opts *Options) ([]stacks.Service, error) {
+	return []stacks.Service{{
+		Name: "backend",
+		Dir:  filepath.Join(opts.ProjectRoot, "backend"),
+		Cmd:  pypkg.Python() + " -m app --reload",
+		Port: opts.Port,
+	}}, nil
+}
+
+func (fastapi) Rollback(ctx context.Context, opts *Options) error {
+	backendDir := filepath.Join(opts.ProjectRoot, "backend")
+
+	if err := fsutil.RemoveDir(filepath.Join(backendDir, pypkg.VenvDir)); err != nil {
+		return fmt.Errorf("remove venv: %w", err)
+	}
+	if err := fsutil.RemoveDir(backendDir); err != nil {
+		return fmt.Errorf("remove backend dir: %w", err)
+	}
+
+	return nil
+}
@@ -140,36 +140,31 @@ func (firebase) Generate(ctx context.Context, opts *Options) error {
 		return fmt.Errorf("npm install firebase: %w", err)
 	}
 
-	templateDir := "firebase/nextjs"
+	templateDir := "firebase/" + opts.Frontend
 	outputDir := filepath.Join(frontendDir)
 
 	if err := fsutil.GenerateFromTemplateDir(templateDir, outputDir); err != nil {
-		return fmt.Errorf("generate firebase nextjs templates: %w", err)
+		return fmt.Errorf("generate firebase %s templates: %w", opts.Frontend, err)
 	}
 
-	fmt.Println("Firebase Next.js frontend files successfully generated under frontend/src/")
+	fmt.Printf("Firebase %s frontend files successfully generated under frontend/src/\n", opts.Frontend)
 	return nil
 }
 
-func (firebase) Post(ctx context.Context, opts *Options) error {
-	// Target the .gitignore inside the frontend directory
-	gitignorePath := filepath.Join(opts.ProjectRoot, "frontend", ".gitignore")
-	if err := fsutil.EnsureFile(gitignorePath); err != nil {
-		return fmt.Errorf("ensure frontend gitignore file: %w", err)
-	}
-
-	// Append only Firebase-specific ignores
-	_ = fsutil.AppendUniqueLines(gitignorePath, []string{
-		"# firebase",
-		".firebase/",
-		".firebasehosting.*",
+// GitIgnore covers the Firebase CLI caches and emulator logs.
+func (firebase) GitIgnore(opts *Options) []string {
+	return []string{
+		"frontend/.firebase/",
+		"frontend/.firebasehosting.*",
 		"firebase-debug.log",
 		"firestore-debug.log",
 		"ui-debug.log",
-	})
+	}
+}
 
+func (firebase) Post(ctx context.Context, opts *Options) error {
 	// Generate and append Firebase credentials to .env.local
-	if err := createCredentials(ctx, opts.ProjectRoot, opts.AppName); err != nil {
+	if err := createCredentials(ctx, opts.ProjectRoot, opts.AppName, opts.Frontend); err != nil {
 		return fmt.Errorf("create credentials: %w", err)
 	}
 
@@ -180,3 +175,10 @@ func (firebase) Post(ctx context.Context, opts *Options) error {
 func (firebase) Rollback(ctx context.Context, opts *Options) error {
 	return nil
 }
+
+// Requires a client runtime, since the Firebase Web SDK keeps the session in
+// the browser, and a frontend with sign-in templates. Checking both before Init
+// matters: Init creates a real Firebase project that Rollback can't remove.
+func (firebase) Requires() []string {
+	return []string{stacks.ClientRuntime, stacks.FirebaseUI}
+}
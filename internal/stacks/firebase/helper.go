@@ -11,7 +11,19 @@ import (
 	"github.com/b-jonathan/taco/internal/fsutil"
 )
 
-func createCredentials(ctx context.Context, projectRoot string, appName string) error {
+// publicEnv is the env file and browser-visible prefix of each frontend with
+// firebase templates (see templates/firebase/<frontend>).
+var publicEnv = map[string]struct{ file, prefix string }{
+	"nextjs":    {".env.local", "NEXT_PUBLIC_"},
+	"sveltekit": {".env", "PUBLIC_"},
+}
+
+func createCredentials(ctx context.Context, projectRoot string, appName string, frontend string) error {
+	env, ok := publicEnv[frontend]
+	if !ok {
+		return fmt.Errorf("no firebase env layout for frontend '%s'", frontend)
+	}
+
 	projectID := fmt.Sprintf("%s-taco", appName)
 	fmt.Printf("Fetching Firebase Web App credentials for project '%s'...\n", projectID)
 
@@ -34,17 +46,17 @@ func createCredentials(ctx context.Context, projectRoot string, appName string)
 
 	lines := []string{
 		"# --- Firebase Credentials ---",
-		fmt.Sprintf("NEXT_PUBLIC_FIREBASE_API_KEY=%s", cfg["apiKey"]),
-		fmt.Sprintf("NEXT_PUBLIC_FIREBASE_AUTH_DOMAIN=%s", cfg["authDomain"]),
-		fmt.Sprintf("NEXT_PUBLIC_FIREBASE_PROJECT_ID=%s", cfg["projectId"]),
-		fmt.Sprintf("NEXT_PUBLIC_FIREBASE_STORAGE_BUCKET=%s", cfg["storageBucket"]),
-		fmt.Sprintf("NEXT_PUBLIC_FIREBASE_MESSAGING_SENDER_ID=%s", cfg["messagingSenderId"]),
-		fmt.Sprintf("NEXT_PUBLIC_FIREBASE_APP_ID=%s", cfg["appId"]),
+		fmt.Sprintf("%sFIREBASE_API_KEY=%s", env.prefix, cfg["apiKey"]),
+		fmt.Sprintf("%sFIREBASE_AUTH_DOMAIN=%s", env.prefix, cfg["authDomain"]),
+		fmt.Sprintf("%sFIREBASE_PROJECT_ID=%s", env.prefix, cfg["projectId"]),
+		fmt.Sprintf("%sFIREBASE_STORAGE_BUCKET=%s", env.prefix, cfg["storageBucket"]),
+		fmt.Sprintf("%sFIREBASE_MESSAGING_SENDER_ID=%s", env.prefix, cfg["messagingSenderId"]),
+		fmt.Sprintf("%sFIREBASE_APP_ID=%s", env.prefix, cfg["appId"]),
 	}
 
-	envPath := filepath.Join(projectRoot, "frontend", ".env.local")
+	envPath := filepath.Join(projectRoot, "frontend", env.file)
 	if err := fsutil.EnsureFile(envPath); err != nil {
-		return fmt.Errorf("ensure .env.local: %w", err)
+		return fmt.Errorf("ensure %s: %w", env.file, err)
 	}
 	if err := fsutil.AppendUniqueLines(envPath, lines); err != nil {
 		return fmt.Errorf("append firebase env vars: %w", err)
@@ -0,0 +1,106 @@
+package golang
+
+import (
+	"context"
+	"fmt"
+	"os/exec"
+	"path/filepath"
+	"regexp"
+	"strings"
+
+	"github.com/b-jonathan/taco/internal/execx"
+	"github.com/b-jonathan/taco/internal/fsutil"
+	"github.com/b-jonathan/taco/internal/stacks"
+	"github.com/spf13/afero"
+)
+
+type Stack = stacks.Stack
+type Options = stacks.Options
+
+type golang struct{}
+
+func New() Stack { return &golang{} }
+
+func (golang) Type() string { return "backend" }
+func (golang) Name() string { return "go" }
+
+// templateData is passed to the go templates.
+type templateData struct {
+	AppName string
+}
+
+var invalidModuleChars = regexp.MustCompile(`[^a-z0-9._~-]+`)
+
+// ModulePath derives a go.mod module path from the app name: "My App!" -> "my-app".
+func ModulePath(appName string) string {
+	m := strings.Trim(invalidModuleChars.ReplaceAllString(strings.ToLower(appName), "-"), "-.")
+	if m == "" {
+		return "app"
+	}
+	return m
+}
+
+func (golang) Init(ctx context.Context, opts *Options) error {
+	if _, err := exec.LookPath("go"); err != nil {
+		return fmt.Errorf("go not found on PATH; install it from https://go.dev/dl/")
+	}
+
+	backendDir := filepath.Join(opts.ProjectRoot, "backend")
+	if err := fsutil.Fs.MkdirAll(backendDir, 0o755); err != nil {
+		return fmt.Errorf("mkdir: %w", err)
+	}
+
+	if err := execx.RunCmd(ctx, backendDir, "go mod init "+ModulePath(opts.AppName)); err != nil {
+		return fmt.Errorf("go mod init: %w", err)
+	}
+	if err := execx.RunCmd(ctx, backendDir, "go get github.com/joho/godotenv@v1.5.1"); err != nil {
+		return fmt.Errorf("go get godotenv: %w", err)
+	}
+	return nil
+}
+
+func (golang) Generate(ctx context.Context, opts *Options) error {
+	backendDir := filepath.Join(opts.ProjectRoot, "backend")
+	data := templateData{AppName: opts.AppName}
+
+	if err := fsutil.GenerateFromTemplateDirData("go", backendDir, data); err != nil {
+		return fmt.Errorf("generate go templates: %w", err)
+	}
+	if err := execx.RunCmd(ctx, backendDir, "go mod tidy"); err != nil {
+		return fmt.Errorf("go mod tidy: %w", err)
+	}
+	return nil
+}
+
+func (golang) GitIgnore(opts *Options) []string {
+	return []string{"backend/bin/", "backend/.env*"}
+}
+
+func (golang) Post(ctx context.Context, opts *Options) error {
+	path := filepath.Join(opts.ProjectRoot, "backend", ".env")
+	// loaded by godotenv in cmd/server/main.go
+	content := fmt.Sprintf("PORT=%d\nFRONTEND_ORIGIN=%s\n", opts.Port, opts.FrontendURL)
+	if err := afero.WriteFile(fsutil.Fs, path, []byte(content), 0o644); err != nil {
+		return fmt.Errorf("write %s: %w", path, err)
+	}
+	return nil
+}
+
+func (golang) Services(ctx context.Context, opts *Options) ([]stacks.Service, error) {
+	return []stacks.Service{{
+		Name: "backend",
+		Dir:  filepath.Join(opts.ProjectRoot, "backend"),
+		Cmd:  "go run ./cmd/server",
+		Port: opts.Port,
+	}}, nil
+}
+
+func (golang) Rollback(ctx context.Context, opts *Options) error {
+	backendDir := filepath.Join(opts.ProjectRoot, "backend")
+
+	if err := fsutil.RemoveDir(backendDir); err != nil {
+		return fmt.Errorf("remove backend dir: %w", err)
+	}
+
+	return nil
+}
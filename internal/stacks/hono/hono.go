@@ -0,0 +1,197 @@
+package hono
+
+import (
+	"context"
+	"fmt"
+	"os/exec"
+	"path/filepath"
+	"regexp"
+	"strings"
+
+	"github.com/b-jonathan/taco/internal/execx"
+	"github.com/b-jonathan/taco/internal/fsutil"
+	"github.com/b-jonathan/taco/internal/nodepkg"
+	"github.com/b-jonathan/taco/internal/prompt"
+	"github.com/b-jonathan/taco/internal/stacks"
+	"github.com/spf13/afero"
+)
+
+type Stack = stacks.Stack
+type Options = stacks.Options
+
+// Runtimes the app can be generated for.
+const (
+	Node    = "node"
+	Bun     = "bun"
+	Workers = "workers" // Cloudflare Workers, run locally with wrangler dev
+)
+
+// runtimeChoices maps the prompt labels to runtimes.
+var runtimeChoices = map[string]string{
+	"Node":                              Node,
+	"Bun":                               Bun,
+	"Cloudflare Workers (wrangler dev)": Workers,
+}
+
+type hono struct {
+	runtime string
+}
+
+func New() Stack { return &hono{runtime: Node} }
+
+func (*hono) Type() string { return "backend" }
+func (*hono) Name() string { return "hono" }
+
+// templateData is passed to the hono templates.
+type templateData struct {
+	Runtime    string
+	WorkerName string
+}
+
+var invalidWorkerChars = regexp.MustCompile(`[^a-z0-9-]+`)
+
+// RuntimeOf tells which runtime the backend in backendDir was generated for.
+func RuntimeOf(backendDir string) string {
+	if _, err := fsutil.Fs.Stat(filepath.Join(backendDir, "wrangler.jsonc")); err == nil {
+		return Workers
+	}
+	if dev, _ := nodepkg.Script(backendDir, "dev"); strings.HasPrefix(dev, "bun ") {
+		return Bun
+	}
+	return Node
+}
+
+func (h *hono) Init(ctx context.Context, opts *Options) error {
+	if prompt.IsTTY() {
+		choice, err := prompt.CreateSurveySelect("Choose a Hono runtime:\n", []string{"Node", "Bun", "Cloudflare Workers (wrangler dev)"}, prompt.AskOpts{})
+		if err != nil {
+			return err
+		}
+		h.runtime = runtimeChoices[choice]
+	}
+	if h.runtime == Bun {
+		if _, err := exec.LookPath("bun"); err != nil {
+			return fmt.Errorf("bun not found on PATH; install it from https://bun.sh")
+		}
+	}
+
+	backendDir := filepath.Join(opts.ProjectRoot, "backend")
+	if err := fsutil.Fs.MkdirAll(filepath.Join(backendDir, "src"), 0o755); err != nil {
+		return fmt.Errorf("mkdir: %w", err)
+	}
+
+	if err := execx.RunCmd(ctx, backendDir, "npm init -y"); err != nil {
+		return fmt.Errorf("npm init: %w", err)
+	}
+	dependencies := []string{"hono"}
+	devDependencies := []string{
+		"typescript",
+		"eslint",
+		"@eslint/js",
+		"globals",
+		"typescript-eslint",
+		"eslint-config-prettier",
+		"prettier",
+	}
+	switch h.runtime {
+	case Node:
+		dependencies = append(dependencies, "@hono/node-server", "dotenv")
+		devDependencies = append(devDependencies, "@types/node", "tsx")
+	case Bun:
+		devDependencies = append(devDependencies, "@types/bun")
+	case Workers:
+		devDependencies = append(devDependencies, "wrangler", "@cloudflare/workers-types")
+	}
+	if err := execx.RunCmd(ctx, backendDir, "npm install "+strings.Join(dependencies, " ")); err != nil {
+		return fmt.Errorf("npm install hono: %w", err)
+	}
+	if err := execx.RunCmd(ctx, backendDir, "npm install -D "+strings.Join(devDependencies, " ")); err != nil {
+		return fmt.Errorf("npm install dev deps: %w", err)
+	}
+
+	return nil
+}
+
+func (h *hono) Generate(ctx context.Context, opts *Options) error {
+	backendDir := filepath.Join(opts.ProjectRoot, "backend")
+	data := templateData{
+		Runtime:    h.runtime,
+		WorkerName: strings.Trim(invalidWorkerChars.ReplaceAllString(strings.ToLower(opts.AppName), "-"), "-"),
+	}
+
+	if err := fsutil.GenerateFromTemplateDirData("hono/base", backendDir, data); err != nil {
+		return fmt.Errorf("generate hono templates: %w", err)
+	}
+	if err := fsutil.GenerateFromTemplateDirData("hono/"+h.runtime, backendDir, data); err != nil {
+		return fmt.Errorf("generate hono %s templates: %w", h.runtime, err)
+	}
+
+	scripts := map[string]string{
+		"lint-check": "eslint . && prettier --check .",
+		"lint-fix":   "eslint . --fix && prettier --write .",
+	}
+	switch h.runtime {
+	case Node:
+		scripts["dev"] = "tsx watch src/index.ts"
+		scripts["build"] = "tsc -p tsconfig.json"
+		scripts["start"] = "node dist/index.js"
+	case Bun:
+		// Bun runs TypeScript directly; build only type-checks
+		scripts["dev"] = "bun run --hot src/index.ts"
+		scripts["build"] = "tsc --noEmit"
+		scripts["start"] = "bun src/index.ts"
+	case Workers:
+		scripts["dev"] = fmt.Sprintf("wrangler dev --port %d", opts.Port)
+		scripts["build"] = "tsc --noEmit"
+		scripts["deploy"] = "wrangler deploy"
+	}
+	params := nodepkg.InitPackageParams{Name: "backend", Main: "src/index.ts", Scripts: scripts}
+	if err := nodepkg.InitPackage(backendDir, params); err != nil {
+		return fmt.Errorf("init hono package.json: %w", err)
+	}
+
+	return nil
+}
+
+// Excludes a container image: docker has no Dockerfile template for Hono,
+// whose runtime (Node, Bun or Workers) is only picked in Init.
+func (*hono) Excludes() []string {
+	return []string{stacks.ContainerImage}
+}
+
+func (h *hono) GitIgnore(opts *Options) []string {
+	return []string{"backend/node_modules/", "backend/dist/", "backend/.env*", "backend/.dev.vars", "backend/.wrangler/"}
+}
+
+func (h *hono) Post(ctx context.Context, opts *Options) error {
+	// wrangler dev reads local variables from .dev.vars instead of .env
+	name := ".env"
+	if h.runtime == Workers {
+		name = ".dev.vars"
+	}
+	path := filepath.Join(opts.ProjectRoot, "backend", name)
+	content := fmt.Sprintf("PORT=%d\nFRONTEND_ORIGIN=%s\n", opts.Port, opts.FrontendURL)
+	if err := afero.WriteFile(fsutil.Fs, path, []byte(content), 0o644); err != nil {
+		return fmt.Errorf("write %s: %w", path, err)
+	}
+	return nil
+}
+
+func (*hono) Services(ctx context.Context, opts *Options) ([]stacks.Service, error) {
+	return []stacks.Service{{
+		Name: "backend",
+		Dir:  filepath.Join(opts.ProjectRoot, "backend"),
+		Cmd:  "npm run dev",
+		Port: opts.Port,
+	}}, nil
+}
+
+func (*hono) Rollback(ctx context.Context, opts *Options) error {
+	backendDir := filepath.Join(opts.ProjectRoot, "backend")
+
+	if err := fsutil.RemoveDir(backendDir); err != nil {
+		return fmt.Errorf("remove backend dir: %w", err)
+	}
+
+	return nil
+}
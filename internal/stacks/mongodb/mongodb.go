@@ -4,16 +4,25 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"net/url"
+	"os/exec"
 	"path/filepath"
+	"slices"
+	"strconv"
 	"strings"
 	"time"
 
 	"github.com/AlecAivazis/survey/v2"
 	"github.com/b-jonathan/taco/internal/execx"
 	"github.com/b-jonathan/taco/internal/fsutil"
+	"github.com/b-jonathan/taco/internal/logx"
 	"github.com/b-jonathan/taco/internal/prompt"
+	"github.com/b-jonathan/taco/internal/pypkg"
 	"github.com/b-jonathan/taco/internal/stacks"
-	"github.com/spf13/afero"
+	"github.com/b-jonathan/taco/internal/stacks/hono"
+	"github.com/b-jonathan/taco/internal/stacks/wire"
+	"github.com/joho/godotenv"
+	"go.mongodb.org/mongo-driver/bson"
 	"go.mongodb.org/mongo-driver/mongo"
 	"go.mongodb.org/mongo-driver/mongo/options"
 )
@@ -21,14 +30,27 @@ import (
 type Stack = stacks.Stack
 type Options = stacks.Options
 
-type mongodb struct{}
+// Drivers the Node backends can use.
+const (
+	Native   = "native"
+	Mongoose = "mongoose"
+)
+
+// nodeBackends can choose the driver; go and fastapi use the native one.
+var nodeBackends = []string{"express", "fastify", "hono", "nestjs"}
+
+type mongodb struct {
+	driver string
+	// items Seed inserted with Mongoose, so Rollback removes only those
+	seeded []any
+}
 
-func New() Stack { return &mongodb{} }
+func New() Stack { return &mongodb{driver: Native} }
 
-func (mongodb) Type() string { return "database" }
-func (mongodb) Name() string { return "mongodb" }
+func (*mongodb) Type() string { return "database" }
+func (*mongodb) Name() string { return "mongodb" }
 
-func (mongodb) Seed(ctx context.Context, opts *Options) error {
+func (m *mongodb) Seed(ctx context.Context, opts *Options) error {
 	if opts.DatabaseURI == "" {
 		return fmt.Errorf("DatabaseURI is empty — did Init() run?")
 	}
@@ -53,6 +75,9 @@ func (mongodb) Seed(ctx context.Context, opts *Options) error {
 	// Use project name as DB name
 	dbName := opts.AppName
 	db := client.Database(dbName)
+	if m.driver == Mongoose {
+		return m.seedItems(ctx, db)
+	}
 	// Create a test collection
 	col := db.Collection("seed_test")
 
@@ -66,7 +91,44 @@ func (mongodb) Seed(ctx context.Context, opts *Options) error {
 	return nil
 }
 
-func (mongodb) Init(ctx context.Context, opts *Options) error {
+// seedItems inserts sample items for the generated Mongoose model into an
+// empty items collection.
+func (m *mongodb) seedItems(ctx context.Context, db *mongo.Database) error {
+	col := db.Collection("items")
+	n, err := col.CountDocuments(ctx, bson.D{})
+	if err != nil {
+		return fmt.Errorf("count items: %w", err)
+	}
+	if n > 0 {
+		fmt.Printf("Seed skipped: %s.items is not empty\n", db.Name())
+		return nil
+	}
+	// the fields Mongoose's timestamps option maintains
+	now := time.Now()
+	var docs []any
+	for _, name := range []string{"First item", "Second item"} {
+		docs = append(docs, bson.M{"name": name, "done": false, "createdAt": now, "updatedAt": now})
+	}
+	res, err := col.InsertMany(ctx, docs)
+	if err != nil {
+		return fmt.Errorf("insert items: %w", err)
+	}
+	m.seeded = res.InsertedIDs
+	fmt.Printf("Seed successful!\nDatabase: %s\nCollection: items\nInserted: %d\n", db.Name(), len(res.InsertedIDs))
+	return nil
+}
+
+func (m *mongodb) Init(ctx context.Context, opts *Options) error {
+	if prompt.IsTTY() && slices.Contains(nodeBackends, opts.Backend) {
+		d, err := prompt.CreateSurveySelect("Choose a MongoDB driver:\n", []string{"Native driver", "Mongoose"}, prompt.AskOpts{Default: "Native driver"})
+		if err != nil {
+			return err
+		}
+		if d == "Mongoose" {
+			m.driver = Mongoose
+		}
+	}
+
 	var mongoURI string
 	// Step 1: Ask local vs auth
 	var choice string
@@ -133,62 +195,82 @@ func (mongodb) Init(ctx context.Context, opts *Options) error {
 	return nil
 }
 
-func (mongodb) Generate(ctx context.Context, opts *Options) error {
+func (m *mongodb) Generate(ctx context.Context, opts *Options) error {
 	backendDir := filepath.Join(opts.ProjectRoot, "backend")
 	if !fsutil.ValidateDependency("mongodb", opts.Backend) {
 		return fmt.Errorf("mongodb cannot be used with backend '%s'", opts.Backend)
 	}
-	if err := execx.RunCmd(ctx, backendDir, "npm install mongodb"); err != nil {
-		return fmt.Errorf("npm install mongodb: %w", err)
+	if opts.Backend == "hono" && hono.RuntimeOf(backendDir) == hono.Workers {
+		return fmt.Errorf("mongodb needs a TCP connection, which the Hono Cloudflare Workers runtime does not offer; choose the node or bun runtime")
 	}
-	if err := execx.RunCmd(ctx, backendDir, "npm install -D @types/mongodb"); err != nil {
-		return fmt.Errorf("npm install @types/mongodb: %w", err)
+	if m.driver == Mongoose {
+		return generateMongoose(ctx, opts)
 	}
-
-	templateDir := "mongodb/express"
-	outputDir := filepath.Join(backendDir, "src")
-
-	if err := fsutil.GenerateFromTemplateDir(templateDir, outputDir); err != nil {
-		return fmt.Errorf("generate mongodb templates: %w", err)
+	switch opts.Backend {
+	case "go":
+		if err := execx.RunCmd(ctx, backendDir, "go get go.mongodb.org/mongo-driver/v2@v2.3.0"); err != nil {
+			return fmt.Errorf("go get mongo-driver: %w", err)
+		}
+	case "fastapi":
+		// 4.13 is the first release with a stable asyncio API
+		if err := pypkg.Add(ctx, backendDir, "pymongo>=4.13"); err != nil {
+			return fmt.Errorf("add pymongo: %w", err)
+		}
+	default:
+		// the driver ships its own types
+		if err := execx.RunCmd(ctx, backendDir, "npm install mongodb"); err != nil {
+			return fmt.Errorf("npm install mongodb: %w", err)
+		}
 	}
 
-	indexPath := filepath.Join(backendDir, "src", "index.ts")
-	indexBytes, err := afero.ReadFile(fsutil.Fs, indexPath)
-	if err != nil {
-		return fmt.Errorf("read index.ts: %w", err)
+	if err := wire.Apply("mongodb", opts); err != nil {
+		return fmt.Errorf("wire mongodb into %s: %w", opts.Backend, err)
 	}
 
-	src := string(indexBytes)
-
-	if !strings.Contains(src, "connectDB") {
-		src = strings.Replace(src, "// [DATABASE IMPORT]", `
-import { connectDB } from "./db/client";`, 1)
+	if opts.Backend == "go" {
+		// sort the inserted import and mark the driver as a direct dependency
+		if err := execx.RunCmd(ctx, backendDir, "gofmt -w cmd/server/main.go"); err != nil {
+			return fmt.Errorf("gofmt: %w", err)
+		}
+		if err := execx.RunCmd(ctx, backendDir, "go mod tidy"); err != nil {
+			return fmt.Errorf("go mod tidy: %w", err)
+		}
 	}
+	return nil
+}
 
-	if !strings.Contains(src, "/seed") {
-		route, err := fsutil.RenderTemplate("mongodb/express/seed.tmpl")
-		if err != nil {
-			return fmt.Errorf("render seed route template: %w", err)
+// generateMongoose writes the Mongoose connection module, the Item model and
+// a CRUD router for /items instead of the /seed route.
+func generateMongoose(ctx context.Context, opts *Options) error {
+	backendDir := filepath.Join(opts.ProjectRoot, "backend")
+	if !fsutil.ValidateDependency("mongodb/mongoose", opts.Backend) {
+		return fmt.Errorf("mongoose cannot be used with backend '%s'", opts.Backend)
+	}
+	deps := "mongoose"
+	if opts.Backend == "nestjs" {
+		deps += " @nestjs/mongoose"
+	}
+	if err := execx.RunCmd(ctx, backendDir, "npm install "+deps); err != nil {
+		return fmt.Errorf("npm install mongoose: %w", err)
+	}
+	// NestJS declares its model with @nestjs/mongoose decorators instead
+	if opts.Backend != "nestjs" {
+		if err := fsutil.GenerateFromTemplateDir("mongodb/mongoose/shared", backendDir); err != nil {
+			return fmt.Errorf("generate mongoose templates: %w", err)
 		}
-		src = strings.Replace(src, "// [DATABASE ROUTE]", string(route), 1)
 	}
-
-	updated := fsutil.FileInfo{
-		Path:    indexPath,
-		Content: []byte(src),
+	if err := wire.Apply("mongodb/mongoose", opts); err != nil {
+		return fmt.Errorf("wire mongoose into %s: %w", opts.Backend, err)
 	}
-
-	return fsutil.WriteFile(updated)
+	return nil
 }
 
-func (mongodb) Post(ctx context.Context, opts *Options) error {
-	// gitignorePath := filepath.Join(opts.ProjectRoot, ".gitignore")
-	// if err := fsutil.EnsureFile(gitignorePath); err != nil {
-	//  return fmt.Errorf("ensure gitignore file: %w", err)
-	// }
+// GitIgnore keeps the local data directory used by `taco dev` out of git.
+func (*mongodb) GitIgnore(opts *Options) []string {
+	return []string{".taco/"}
+}
 
-	// _ = fsutil.AppendUniqueLines(gitignorePath,
-	//  []string{"backend/node_modules/", "backend/dist/", "backend/.env*"})
+func (*mongodb) Post(ctx context.Context, opts *Options) error {
 	path := filepath.Join(opts.ProjectRoot, "backend", ".env")
 	// dir := filepath.Dir(path)
 	// if err := os.MkdirAll(dir, 0o755); err != nil {
@@ -200,8 +282,41 @@ func (mongodb) Post(ctx context.Context, opts *Options) error {
 	return nil
 }
 
-func (mongodb) Rollback(ctx context.Context, opts *Options) error {
-	if opts.DatabaseURI == "" {
+// Services starts a local mongod for `taco dev` when the backend points at
+// localhost. Remote URIs (Atlas etc.) need nothing started.
+func (*mongodb) Services(ctx context.Context, opts *Options) ([]stacks.Service, error) {
+	env, err := godotenv.Read(filepath.Join(opts.ProjectRoot, "backend", ".env"))
+	if err != nil {
+		return nil, fmt.Errorf("read backend .env: %w", err)
+	}
+	u, err := url.Parse(env["MONGODB_URI"])
+	if err != nil || (u.Hostname() != "localhost" && u.Hostname() != "127.0.0.1") {
+		return nil, nil
+	}
+	port := 27017
+	if p, err := strconv.Atoi(u.Port()); err == nil {
+		port = p
+	}
+
+	if _, err := exec.LookPath("mongod"); err != nil {
+		logx.Warnf("mongod not found on PATH; make sure MongoDB is running on port %d", port)
+		return nil, nil
+	}
+	dataDir := filepath.Join(opts.ProjectRoot, ".taco", "mongo")
+	if err := fsutil.Fs.MkdirAll(dataDir, 0o755); err != nil {
+		return nil, fmt.Errorf("mkdir %s: %w", dataDir, err)
+	}
+	return []stacks.Service{{
+		Name:  "mongodb",
+		Dir:   opts.ProjectRoot,
+		Cmd:   fmt.Sprintf("mongod --dbpath %s --port %d", filepath.Join(".taco", "mongo"), port),
+		Port:  port,
+		Reuse: true,
+	}}, nil
+}
+
+func (m *mongodb) Rollback(ctx context.Context, opts *Options) error {
+	if opts.DatabaseURI == "" || (m.driver == Mongoose && len(m.seeded) == 0) {
 		return nil
 	}
 	client, err := mongo.Connect(ctx, options.Client().ApplyURI(opts.DatabaseURI))
@@ -211,6 +326,13 @@ func (mongodb) Rollback(ctx context.Context, opts *Options) error {
 	defer func() { _ = client.Disconnect(ctx) }()
 
 	db := client.Database(opts.AppName)
+	if m.driver == Mongoose {
+		if _, err := db.Collection("items").DeleteMany(ctx, bson.M{"_id": bson.M{"$in": m.seeded}}); err != nil {
+			return fmt.Errorf("delete seeded items: %w", err)
+		}
+		fmt.Printf("Rolled back MongoDB seed data (deleted %d items)\n", len(m.seeded))
+		return nil
+	}
 	col := db.Collection("seed_test")
 
 	if err := col.Drop(ctx); err != nil {
@@ -0,0 +1,117 @@
+package mysql
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"net/url"
+	"strings"
+	"time"
+
+	driver "github.com/go-sql-driver/mysql"
+)
+
+// erBadDB is MySQL's "Unknown database" error (ER_BAD_DB_ERROR).
+const erBadDB = 1049
+
+// EnsureMySQLURI checks that uri is a mysql:// URL naming a database and that
+// the server answers. A database that doesn't exist yet is fine: Generate
+// creates it.
+func EnsureMySQLURI(uri string) error {
+	cfg, err := driverConfig(uri)
+	if err != nil {
+		return err
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), 3*time.Second)
+	defer cancel()
+	err = ping(ctx, cfg)
+	if isMissingDatabase(err) {
+		return nil
+	}
+	if err != nil {
+		return fmt.Errorf("failed to connect: %w", err)
+	}
+	return nil
+}
+
+// driverConfig turns the mysql:// URL Prisma and mysql2 use into the Go
+// driver's config.
+func driverConfig(uri string) (*driver.Config, error) {
+	if !strings.HasPrefix(uri, "mysql://") {
+		return nil, errors.New("must start with mysql://")
+	}
+	u, err := url.Parse(uri)
+	if err != nil {
+		return nil, fmt.Errorf("parse: %w", err)
+	}
+	cfg := driver.NewConfig()
+	cfg.Net = "tcp"
+	cfg.Addr = u.Host
+	if u.Port() == "" {
+		cfg.Addr = u.Hostname() + ":3306"
+	}
+	cfg.User = u.User.Username()
+	cfg.Passwd, _ = u.User.Password()
+	cfg.DBName = strings.TrimPrefix(u.Path, "/")
+	if cfg.DBName == "" {
+		return nil, errors.New("no database name in the URI path")
+	}
+	// the TLS parameters of Prisma (sslaccept) and mysql2 (ssl)
+	q := u.Query()
+	switch {
+	case q.Get("sslaccept") == "accept_invalid_certs":
+		cfg.TLSConfig = "skip-verify"
+	case q.Get("sslaccept") == "strict", q.Has("ssl") && q.Get("ssl") != "false":
+		cfg.TLSConfig = "true"
+	}
+	return cfg, nil
+}
+
+func ping(ctx context.Context, cfg *driver.Config) error {
+	db, err := open(cfg)
+	if err != nil {
+		return err
+	}
+	defer db.Close()
+	return db.PingContext(ctx)
+}
+
+func open(cfg *driver.Config) (*sql.DB, error) {
+	conn, err := driver.NewConnector(cfg)
+	if err != nil {
+		return nil, err
+	}
+	return sql.OpenDB(conn), nil
+}
+
+// isMissingDatabase reports whether err is "Unknown database" (1049).
+func isMissingDatabase(err error) bool {
+	var myErr *driver.MySQLError
+	return errors.As(err, &myErr) && myErr.Number == erBadDB
+}
+
+// serverOnly returns cfg without a database, used to create and drop the app
+// database.
+func serverOnly(cfg *driver.Config) *driver.Config {
+	s := cfg.Clone()
+	s.DBName = ""
+	return s
+}
+
+// quote quotes a MySQL identifier.
+func quote(name string) string {
+	return "`" + strings.ReplaceAll(name, "`", "``") + "`"
+}
+
+func createDatabase(ctx context.Context, cfg *driver.Config) error {
+	db, err := open(serverOnly(cfg))
+	if err != nil {
+		return fmt.Errorf("connect mysql to create %s: %w", cfg.DBName, err)
+	}
+	defer db.Close()
+	if _, err := db.ExecContext(ctx, "CREATE DATABASE "+quote(cfg.DBName)); err != nil {
+		return fmt.Errorf("create database %s: %w", cfg.DBName, err)
+	}
+	return nil
+}
@@ -0,0 +1,103 @@
+package nestjs
+
+import (
+	"context"
+	"fmt"
+	"path/filepath"
+	"strings"
+
+	"github.com/b-jonathan/taco/internal/execx"
+	"github.com/b-jonathan/taco/internal/fsutil"
+	"github.com/b-jonathan/taco/internal/nodepkg"
+	"github.com/b-jonathan/taco/internal/stacks"
+	"github.com/spf13/afero"
+)
+
+type Stack = stacks.Stack
+type Options = stacks.Options
+
+type nestjs struct{}
+
+func New() Stack { return &nestjs{} }
+
+func (nestjs) Type() string { return "backend" }
+func (nestjs) Name() string { return "nestjs" }
+
+func (nestjs) Init(ctx context.Context, opts *Options) error {
+	if err := fsutil.Fs.MkdirAll(opts.ProjectRoot, 0o755); err != nil {
+		return fmt.Errorf("mkdir: %w", err)
+	}
+	// every question the Nest CLI would ask is answered by a flag; pinned like create-vite
+	nestFlags := []string{
+		"--yes",
+		"@nestjs/cli@11.0.10",
+		"new",
+		"backend",
+		"--package-manager", "npm",
+		"--language", "ts",
+		"--strict",
+		"--skip-git",
+	}
+	if err := execx.RunCmd(ctx, opts.ProjectRoot, "npx "+strings.Join(nestFlags, " ")); err != nil {
+		return fmt.Errorf("nest new: %w", err)
+	}
+
+	backendDir := filepath.Join(opts.ProjectRoot, "backend")
+	if err := execx.RunCmd(ctx, backendDir, "npm install @nestjs/config"); err != nil {
+		return fmt.Errorf("npm install @nestjs/config: %w", err)
+	}
+	return nil
+}
+
+func (nestjs) Generate(ctx context.Context, opts *Options) error {
+	backendDir := filepath.Join(opts.ProjectRoot, "backend")
+
+	if err := fsutil.GenerateFromTemplateDir("nestjs", backendDir); err != nil {
+		return fmt.Errorf("generate nestjs templates: %w", err)
+	}
+
+	packageParams := nodepkg.InitPackageParams{
+		Name: "backend",
+		Scripts: map[string]string{
+			"lint-check": "eslint . && prettier --check \"src/**/*.ts\" \"test/**/*.ts\"",
+			"lint-fix":   "eslint . --fix && prettier --write \"src/**/*.ts\" \"test/**/*.ts\"",
+		},
+	}
+	if err := nodepkg.InitPackage(backendDir, packageParams); err != nil {
+		return fmt.Errorf("init nestjs package.json: %w", err)
+	}
+	return nil
+}
+
+func (nestjs) GitIgnore(opts *Options) []string {
+	return []string{"backend/node_modules/", "backend/dist/", "backend/.env*"}
+}
+
+func (nestjs) Post(ctx context.Context, opts *Options) error {
+	path := filepath.Join(opts.ProjectRoot, "backend", ".env")
+	// read by ConfigModule.forRoot in src/app.module.ts
+	content := fmt.Sprintf("PORT=%d\nFRONTEND_ORIGIN=%s\n", opts.Port, opts.FrontendURL)
+	if err := afero.WriteFile(fsutil.Fs, path, []byte(content), 0o644); err != nil {
+		return fmt.Errorf("write %s: %w", path, err)
+	}
+	return nil
+}
+
+func (nestjs) Services(ctx context.Context, opts *Options) ([]stacks.Service, error) {
+	return []stacks.Service{{
+		Name: "backend",
+		Dir:  filepath.Join(opts.ProjectRoot, "backend"),
+		Cmd:  "npm run start:dev",
+		Port: opts.Port,
+	}}, nil
+}
+
+func (nestjs) Rollback(ctx context.Context, opts *Options) error {
+	backendDir := filepath.Join(opts.ProjectRoot, "backend")
+
+	if err := fsutil.RemoveDir(backendDir); err != nil {
+		return fmt.Errorf("remove backend dir: %w", err)
+	}
+
+	return nil
+}
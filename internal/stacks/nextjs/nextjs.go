@@ -115,6 +115,26 @@ func (nextjs) Post(ctx context.Context, opts *Options) error {
 	return nil
 }
 
+// Provides the Firebase sign-in pages under templates/firebase/nextjs.
+func (nextjs) Provides() []string {
+	return []string{stacks.FirebaseUI}
+}
+
+// GitIgnore repeats the create-next-app ignores at the root, so they hold even
+// if frontend/.gitignore is edited away.
+func (nextjs) GitIgnore(opts *Options) []string {
+	return []string{"frontend/node_modules/", "frontend/.next/", "frontend/out/", "frontend/.env*.local"}
+}
+
+func (nextjs) Services(ctx context.Context, opts *Options) ([]stacks.Service, error) {
+	return []stacks.Service{{
+		Name: "frontend",
+		Dir:  filepath.Join(opts.ProjectRoot, "frontend"),
+		Cmd:  "npm run dev",
+		Port: 3000,
+	}}, nil
+}
+
 func (nextjs) Rollback(ctx context.Context, opts *Options) error {
 	frontendDir := filepath.Join(opts.ProjectRoot, "frontend")
 
@@ -0,0 +1,123 @@
+package nuxt
+
+import (
+	"context"
+	"fmt"
+	"path/filepath"
+	"strings"
+
+	"github.com/b-jonathan/taco/internal/execx"
+	"github.com/b-jonathan/taco/internal/fsutil"
+	"github.com/b-jonathan/taco/internal/nodepkg"
+	"github.com/b-jonathan/taco/internal/stacks"
+	"github.com/spf13/afero"
+)
+
+type Stack = stacks.Stack
+type Options = stacks.Options
+
+type nuxt struct{}
+
+func New() Stack { return &nuxt{} }
+
+func (nuxt) Type() string { return "frontend" }
+
+func (nuxt) Name() string { return "nuxt" }
+
+func (nuxt) Init(ctx context.Context, opts *Options) error {
+	if err := fsutil.Fs.MkdirAll(opts.ProjectRoot, 0o755); err != nil {
+		return fmt.Errorf("mkdir: %w", err)
+	}
+	// every question nuxi would ask is answered by a flag; pinned like create-vite
+	nuxiFlags := []string{
+		"--yes",
+		"nuxi@3.25.1",
+		"init",
+		"frontend",
+		"--template", "v3",
+		"--packageManager", "npm",
+		"--gitInit=false",
+		"--no-modules",
+	}
+	if err := execx.RunCmd(ctx, opts.ProjectRoot, "npx "+strings.Join(nuxiFlags, " ")); err != nil {
+		return fmt.Errorf("nuxi init: %w", err)
+	}
+
+	frontendDir := filepath.Join(opts.ProjectRoot, "frontend")
+	frontendDeps := []string{
+		"eslint",
+		"@eslint/js",
+		"globals",
+		"typescript",
+		"typescript-eslint",
+		"eslint-plugin-vue",
+		"eslint-config-prettier",
+		"prettier",
+	}
+	if err := execx.RunCmd(ctx, frontendDir, "npm install -D "+strings.Join(frontendDeps, " ")); err != nil {
+		return fmt.Errorf("npm install dev deps: %w", err)
+	}
+	return nil
+}
+
+func (nuxt) Generate(ctx context.Context, opts *Options) error {
+	frontendDir := filepath.Join(opts.ProjectRoot, "frontend")
+
+	if err := fsutil.GenerateFromTemplateDir("nuxt", frontendDir); err != nil {
+		return fmt.Errorf("generate nuxt templates: %w", err)
+	}
+
+	packageParams := nodepkg.InitPackageParams{
+		Name: "frontend",
+		Scripts: map[string]string{
+			"lint-check": "eslint . && prettier --check .",
+			"lint-fix":   "(eslint . --fix || true) && prettier --write .",
+		}}
+
+	if err := nodepkg.InitPackage(frontendDir, packageParams); err != nil {
+		return fmt.Errorf("init nuxt package.json: %w", err)
+	}
+
+	return nil
+}
+
+func (nuxt) Post(ctx context.Context, opts *Options) error {
+	envPath := filepath.Join(opts.ProjectRoot, "frontend", ".env")
+	if err := fsutil.EnsureFile(envPath); err != nil {
+		return fmt.Errorf("ensure .env: %w", err)
+	}
+	// overrides runtimeConfig.public.backendUrl in nuxt.config.ts
+	content := "NUXT_PUBLIC_BACKEND_URL=" + opts.BackendURL + "\n"
+	if err := afero.WriteFile(fsutil.Fs, envPath, []byte(content), 0o644); err != nil {
+		return fmt.Errorf("write %s: %w", envPath, err)
+	}
+	return nil
+}
+
+// Excludes a container image: docker has no Dockerfile template for Nuxt.
+func (nuxt) Excludes() []string {
+	return []string{stacks.ContainerImage}
+}
+
+func (nuxt) GitIgnore(opts *Options) []string {
+	return []string{"frontend/node_modules/", "frontend/.nuxt/", "frontend/.output/", "frontend/.data/", "frontend/.env", "frontend/.env.*"}
+}
+
+func (nuxt) Services(ctx context.Context, opts *Options) ([]stacks.Service, error) {
+	return []stacks.Service{{
+		Name: "frontend",
+		Dir:  filepath.Join(opts.ProjectRoot, "frontend"),
+		Cmd:  "npm run dev",
+		Port: 3000,
+	}}, nil
+}
+
+func (nuxt) Rollback(ctx context.Context, opts *Options) error {
+	frontendDir := filepath.Join(opts.ProjectRoot, "frontend")
+
+	if err := fsutil.RemoveDir(frontendDir); err != nil {
+		return fmt.Errorf("remove frontend dir: %w", err)
+	}
+
+	return nil
+}
@@ -0,0 +1,71 @@
+package postgres
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgconn"
+)
+
+// EnsurePostgresURI checks that uri is a postgres:// URL naming a database and
+// that the server answers. A database that doesn't exist yet is fine: Generate
+// creates it.
+func EnsurePostgresURI(uri string) error {
+	if !strings.HasPrefix(uri, "postgres://") && !strings.HasPrefix(uri, "postgresql://") {
+		return errors.New("must start with postgres:// or postgresql://")
+	}
+	cfg, err := pgx.ParseConfig(uri)
+	if err != nil {
+		return fmt.Errorf("parse: %w", err)
+	}
+	if cfg.Database == "" {
+		return errors.New("no database name in the URI path")
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 3*time.Second)
+	defer cancel()
+	conn, err := pgx.ConnectConfig(ctx, cfg)
+	if isMissingDatabase(err) {
+		return nil
+	}
+	if err != nil {
+		return fmt.Errorf("failed to connect: %w", err)
+	}
+	defer conn.Close(context.Background())
+	return conn.Ping(ctx)
+}
+
+// isMissingDatabase reports whether err is "database does not exist" (3D000).
+func isMissingDatabase(err error) bool {
+	var pgErr *pgconn.PgError
+	return errors.As(err, &pgErr) && pgErr.Code == "3D000"
+}
+
+// maintenance returns cfg pointed at the server's "postgres" database, used to
+// create and drop the app database.
+func maintenance(cfg *pgx.ConnConfig) *pgx.ConnConfig {
+	m := cfg.Copy()
+	m.Database = "postgres"
+	return m
+}
+
+// quote quotes a PostgreSQL identifier.
+func quote(name string) string {
+	return pgx.Identifier{name}.Sanitize()
+}
+
+func createDatabase(ctx context.Context, cfg *pgx.ConnConfig) error {
+	conn, err := pgx.ConnectConfig(ctx, maintenance(cfg))
+	if err != nil {
+		return fmt.Errorf("connect postgres to create %s: %w", cfg.Database, err)
+	}
+	defer conn.Close(ctx)
+	if _, err := conn.Exec(ctx, "CREATE DATABASE "+quote(cfg.Database)); err != nil {
+		return fmt.Errorf("create database %s: %w", cfg.Database, err)
+	}
+	return nil
+}
@@ -0,0 +1,117 @@
+package sqlite
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"github.com/b-jonathan/taco/internal/execx"
+	"github.com/b-jonathan/taco/internal/fsutil"
+	"github.com/b-jonathan/taco/internal/nodepkg"
+	"github.com/b-jonathan/taco/internal/pypkg"
+	"github.com/b-jonathan/taco/internal/stacks"
+	"github.com/b-jonathan/taco/internal/stacks/hono"
+	"github.com/b-jonathan/taco/internal/stacks/wire"
+)
+
+type Stack = stacks.Stack
+type Options = stacks.Options
+
+// DatabasePath is the database file, relative to backend/.
+const DatabasePath = "data/app.db"
+
+type sqlite struct{}
+
+func New() Stack { return &sqlite{} }
+
+func (sqlite) Type() string { return "database" }
+func (sqlite) Name() string { return "sqlite" }
+
+// python reports whether the backend is the Python one, which uses the
+// standard library's sqlite3 instead of better-sqlite3.
+func python(backend string) bool { return backend == "fastapi" }
+
+func (sqlite) Init(ctx context.Context, opts *Options) error {
+	// a file next to the backend: nothing to ask, nothing to start
+	opts.DatabaseURI = DatabasePath
+	return nil
+}
+
+func (sqlite) Generate(ctx context.Context, opts *Options) error {
+	backendDir := filepath.Join(opts.ProjectRoot, "backend")
+	if !fsutil.ValidateDependency("sqlite", opts.Backend) {
+		return fmt.Errorf("sqlite cannot be used with backend '%s'", opts.Backend)
+	}
+	if opts.Backend == "hono" && hono.RuntimeOf(backendDir) != hono.Node {
+		return fmt.Errorf("sqlite uses better-sqlite3, a Node addon; choose the node runtime for hono")
+	}
+	if err := fsutil.Fs.MkdirAll(filepath.Join(backendDir, filepath.Dir(DatabasePath)), 0o755); err != nil {
+		return fmt.Errorf("mkdir data: %w", err)
+	}
+
+	if python(opts.Backend) {
+		return wire.Apply("sqlite", opts)
+	}
+
+	if err := execx.RunCmd(ctx, backendDir, "npm install better-sqlite3 dotenv"); err != nil {
+		return fmt.Errorf("npm install better-sqlite3: %w", err)
+	}
+	if err := execx.RunCmd(ctx, backendDir, "npm install -D @types/better-sqlite3 tsx"); err != nil {
+		return fmt.Errorf("npm install sqlite dev deps: %w", err)
+	}
+	if err := fsutil.GenerateFromTemplateDir("sqlite/shared", backendDir); err != nil {
+		return fmt.Errorf("generate sqlite templates: %w", err)
+	}
+	if err := wire.Apply("sqlite", opts); err != nil {
+		return fmt.Errorf("wire sqlite into %s: %w", opts.Backend, err)
+	}
+	if err := nodepkg.InitPackage(backendDir, nodepkg.InitPackageParams{
+		Scripts: map[string]string{"db:seed": "tsx src/db/seed.ts"},
+	}); err != nil {
+		return fmt.Errorf("add db scripts: %w", err)
+	}
+
+	// the shared templates use double quotes; match the backend's own Prettier config
+	return wire.Format(ctx, opts, "src/db")
+}
+
+// Seed runs the generated seed script, which creates the file and the table
+// on first use and inserts sample rows into an empty table.
+func (sqlite) Seed(ctx context.Context, opts *Options) error {
+	backendDir := filepath.Join(opts.ProjectRoot, "backend")
+	name, args := "npx", []string{"tsx", "src/db/seed.ts"}
+	if python(opts.Backend) {
+		name, args = filepath.Join(backendDir, pypkg.Python()), []string{"-m", "app.seed"}
+	}
+	if _, err := execx.RunArgs(ctx, backendDir, nil, name, args...); err != nil {
+		return fmt.Errorf("seed script: %w", err)
+	}
+	fmt.Printf("Seed successful!\nDatabase: backend/%s\n", DatabasePath)
+	return nil
+}
+
+func (sqlite) Post(ctx context.Context, opts *Options) error {
+	path := filepath.Join(opts.ProjectRoot, "backend", ".env")
+	if err := fsutil.AppendUniqueLines(path, []string{"DATABASE_PATH=" + DatabasePath}); err != nil {
+		return fmt.Errorf("write DATABASE_PATH: %w", err)
+	}
+	return nil
+}
+
+// GitIgnore keeps the database file, and the journal files next to it, out of git.
+func (sqlite) GitIgnore(opts *Options) []string {
+	db := "backend/" + DatabasePath
+	return []string{db, db + "-wal", db + "-shm"}
+}
+
+// Rollback removes the database file; the code goes with the backend.
+func (sqlite) Rollback(ctx context.Context, opts *Options) error {
+	db := filepath.Join(opts.ProjectRoot, "backend", filepath.FromSlash(DatabasePath))
+	for _, f := range []string{db, db + "-wal", db + "-shm"} {
+		if err := fsutil.Fs.Remove(f); err != nil && !os.IsNotExist(err) {
+			return fmt.Errorf("remove %s: %w", f, err)
+		}
+	}
+	return nil
+}
@@ -0,0 +1,298 @@
+// Package sqlorm is the Prisma or Drizzle stack behind the SQL database
+// stacks. Each of them supplies a Dialect: its prompts, driver packages and a
+// Server that creates, checks and drops databases. The ORM templates live in
+// templates/sql/<orm> and are shared; only the files naming the dialect (the
+// Prisma schema, the Drizzle schema, client and config) live in
+// templates/<dialect>/<orm>.
+package sqlorm
+
+import (
+	"context"
+	"fmt"
+	"net/url"
+	"path/filepath"
+	"regexp"
+	"strings"
+
+	"github.com/AlecAivazis/survey/v2"
+	"github.com/b-jonathan/taco/internal/execx"
+	"github.com/b-jonathan/taco/internal/fsutil"
+	"github.com/b-jonathan/taco/internal/nodepkg"
+	"github.com/b-jonathan/taco/internal/prompt"
+	"github.com/b-jonathan/taco/internal/stacks"
+	"github.com/b-jonathan/taco/internal/stacks/hono"
+	"github.com/b-jonathan/taco/internal/stacks/wire"
+)
+
+type Stack = stacks.Stack
+type Options = stacks.Options
+
+// ORMs the backend code can be generated for.
+const (
+	Prisma  = "prisma"
+	Drizzle = "drizzle"
+)
+
+// Dialect is what differs between the SQL servers.
+type Dialect struct {
+	Name       string // stack name and dialect template folder
+	Label      string // for prompts and messages
+	LocalURI   string // format taking the database name
+	Hosted     string // examples for the custom URI choice
+	URIExample string
+	// DrizzleDeps and DrizzleDevDeps are the driver packages Drizzle needs.
+	DrizzleDeps    []string
+	DrizzleDevDeps []string
+	Server         Server
+}
+
+// Server talks to a database server on behalf of the stack.
+type Server interface {
+	// CheckURI validates uri and checks that the server answers. A database
+	// that doesn't exist yet is fine: EnsureDatabase creates it.
+	CheckURI(ctx context.Context, uri string) error
+	// EnsureDatabase makes sure the database exists and answers, creating
+	// it when it is missing, and reports whether it did.
+	EnsureDatabase(ctx context.Context, uri string) (created bool, err error)
+	DropDatabase(ctx context.Context, uri string) error
+	DropTables(ctx context.Context, uri string, tables []string) error
+}
+
+type sqlorm struct {
+	dialect Dialect
+	orm     string
+	// what this run created, so Rollback leaves existing data alone
+	createdDB bool
+	migrated  bool
+}
+
+// New returns the database stack for dialect, generating Prisma code unless
+// Init picks otherwise.
+func New(dialect Dialect) Stack { return &sqlorm{dialect: dialect, orm: Prisma} }
+
+func (*sqlorm) Type() string   { return "database" }
+func (s *sqlorm) Name() string { return s.dialect.Name }
+
+// templateData is passed to the shared ORM templates.
+type templateData struct {
+	AppName string
+}
+
+// migrationTables are the tables each ORM records applied migrations in.
+var migrationTables = map[string]string{
+	Prisma:  "_prisma_migrations",
+	Drizzle: "__drizzle_migrations",
+}
+
+var invalidNameChars = regexp.MustCompile(`[^a-z0-9_]+`)
+
+// DatabaseName derives a database name from the app name: "My App" -> "my_app".
+func DatabaseName(appName string) string {
+	name := strings.Trim(invalidNameChars.ReplaceAllString(strings.ToLower(appName), "_"), "_")
+	if name == "" {
+		return "app"
+	}
+	if name[0] >= '0' && name[0] <= '9' {
+		name = "db_" + name
+	}
+	return name
+}
+
+// databaseOf returns the database named in the path of uri.
+func databaseOf(uri string) string {
+	u, err := url.Parse(uri)
+	if err != nil {
+		return ""
+	}
+	return strings.TrimPrefix(u.Path, "/")
+}
+
+func (s *sqlorm) Init(ctx context.Context, opts *Options) error {
+	d := s.dialect
+	local := fmt.Sprintf(d.LocalURI, DatabaseName(opts.AppName))
+	localAddr := "localhost"
+	if u, err := url.Parse(local); err == nil {
+		localAddr = u.Host
+	}
+
+	if !prompt.IsTTY() {
+		if err := d.Server.CheckURI(ctx, local); err != nil {
+			return fmt.Errorf("no %s server at %s: %w", d.Label, localAddr, err)
+		}
+		opts.DatabaseURI = local
+		fmt.Printf("✅ Final %s URI set: %s\n", d.Label, opts.DatabaseURI)
+		return nil
+	}
+
+	orm, err := prompt.CreateSurveySelect(fmt.Sprintf("Choose an ORM for %s:\n", d.Label), []string{"Prisma", "Drizzle"}, prompt.AskOpts{Default: "Prisma"})
+	if err != nil {
+		return err
+	}
+	s.orm = strings.ToLower(orm)
+
+	// check the server now, before the other stacks are scaffolded
+	localChoice := fmt.Sprintf("Local (default %s)", localAddr)
+	for {
+		choice, err := prompt.CreateSurveySelect(
+			fmt.Sprintf("How do you want to connect to %s?", d.Label),
+			[]string{localChoice, fmt.Sprintf("Custom URI (%s)", d.Hosted)},
+			prompt.AskOpts{Default: localChoice, PageSize: 2},
+		)
+		if err != nil {
+			return err
+		}
+		uri := local
+		if strings.HasPrefix(choice, "Custom") {
+			in, err := prompt.CreateSurveyInput(fmt.Sprintf("Enter your %s connection URI:", d.Label), prompt.AskOpts{
+				Help:      "Example: " + d.URIExample,
+				Validator: survey.Required,
+			})
+			if err != nil {
+				return err
+			}
+			uri = strings.TrimSpace(in)
+		}
+		if err := d.Server.CheckURI(ctx, uri); err != nil {
+			if uri == local {
+				fmt.Printf("No %s server at %s; start one or choose a custom URI: %v\n", d.Label, localAddr, err)
+			} else {
+				fmt.Printf("Invalid %s URI: %v\n", d.Label, err)
+			}
+			continue
+		}
+		opts.DatabaseURI = uri
+		break
+	}
+
+	fmt.Printf("✅ Final %s URI set: %s\n", d.Label, opts.DatabaseURI)
+	return nil
+}
+
+// Seed inserts the sample rows by running the generated seed script.
+func (s *sqlorm) Seed(ctx context.Context, opts *Options) error {
+	if opts.DatabaseURI == "" {
+		return fmt.Errorf("DatabaseURI is empty — did Init() run?")
+	}
+	backendDir := filepath.Join(opts.ProjectRoot, "backend")
+	// .env is written in Post; hand the URL over directly
+	env := []string{"DATABASE_URL=" + opts.DatabaseURI}
+	if _, err := execx.RunArgs(ctx, backendDir, env, "npx", "tsx", "src/db/seed.ts"); err != nil {
+		return fmt.Errorf("seed script: %w", err)
+	}
+	fmt.Println("Seed successful!")
+	return nil
+}
+
+func (s *sqlorm) Generate(ctx context.Context, opts *Options) error {
+	d := s.dialect
+	backendDir := filepath.Join(opts.ProjectRoot, "backend")
+	sharedRoot := "sql/" + s.orm
+	if !fsutil.ValidateDependency(sharedRoot, opts.Backend) {
+		return fmt.Errorf("%s with %s cannot be used with backend '%s'", d.Name, s.orm, opts.Backend)
+	}
+	if opts.Backend == "hono" && hono.RuntimeOf(backendDir) == hono.Workers {
+		return fmt.Errorf("%s needs a TCP connection, which the Hono Cloudflare Workers runtime does not offer; choose the node or bun runtime", d.Name)
+	}
+	created, err := d.Server.EnsureDatabase(ctx, opts.DatabaseURI)
+	if err != nil {
+		return err
+	}
+	s.createdDB = created
+	fmt.Printf("Connected to %s database %s (created: %t)\n", d.Label, databaseOf(opts.DatabaseURI), created)
+
+	// tsx and dotenv run the seed script and the ORM CLIs on every backend
+	deps, devDeps := []string{"dotenv"}, []string{"tsx"}
+	scripts := map[string]string{"db:seed": "tsx src/db/seed.ts"}
+	switch s.orm {
+	case Prisma:
+		// pinned to 6: Prisma 7 changes how the client is generated and configured
+		deps = append(deps, "@prisma/client@6")
+		devDeps = append(devDeps, "prisma@6")
+		scripts["db:migrate"] = "prisma migrate dev"
+		scripts["db:deploy"] = "prisma migrate deploy"
+		// the client is generated code; builds (CI, Docker) need it first
+		scripts["prebuild"] = "prisma generate"
+	case Drizzle:
+		deps = append(append(deps, "drizzle-orm"), d.DrizzleDeps...)
+		devDeps = append(append(devDeps, "drizzle-kit"), d.DrizzleDevDeps...)
+		scripts["db:generate"] = "drizzle-kit generate --config src/db/drizzle.config.ts"
+		scripts["db:migrate"] = "drizzle-kit migrate --config src/db/drizzle.config.ts"
+		scripts["db:deploy"] = scripts["db:migrate"]
+	}
+	if err := execx.RunCmd(ctx, backendDir, "npm install "+strings.Join(deps, " ")); err != nil {
+		return fmt.Errorf("npm install %s: %w", s.orm, err)
+	}
+	if err := execx.RunCmd(ctx, backendDir, "npm install -D "+strings.Join(devDeps, " ")); err != nil {
+		return fmt.Errorf("npm install %s dev deps: %w", s.orm, err)
+	}
+
+	data := templateData{AppName: opts.AppName}
+	for _, root := range []string{sharedRoot + "/shared", d.Name + "/" + s.orm} {
+		if err := fsutil.GenerateFromTemplateDirData(root, backendDir, data); err != nil {
+			return fmt.Errorf("generate %s templates: %w", root, err)
+		}
+	}
+	if err := wire.Apply(sharedRoot, opts); err != nil {
+		return fmt.Errorf("wire %s into %s: %w", d.Name, opts.Backend, err)
+	}
+	if err := nodepkg.InitPackage(backendDir, nodepkg.InitPackageParams{Scripts: scripts}); err != nil {
+		return fmt.Errorf("add db scripts: %w", err)
+	}
+
+	// the shared templates use double quotes; match the backend's own Prettier config
+	if err := wire.Format(ctx, opts, "src/db"); err != nil {
+		return err
+	}
+
+	// .env is written in Post, after the backend's own; hand the URL over directly
+	env := []string{"DATABASE_URL=" + opts.DatabaseURI}
+	var migrate [][]string
+	switch s.orm {
+	case Prisma:
+		migrate = [][]string{{"prisma", "migrate", "dev", "--name", "init"}}
+	case Drizzle:
+		migrate = [][]string{
+			{"drizzle-kit", "generate", "--config", "src/db/drizzle.config.ts", "--name", "init"},
+			{"drizzle-kit", "migrate", "--config", "src/db/drizzle.config.ts"},
+		}
+	}
+	for _, args := range migrate {
+		if _, err := execx.RunArgs(ctx, backendDir, env, "npx", args...); err != nil {
+			return fmt.Errorf("initial migration: %w", err)
+		}
+	}
+	s.migrated = true
+	return nil
+}
+
+func (*sqlorm) Post(ctx context.Context, opts *Options) error {
+	path := filepath.Join(opts.ProjectRoot, "backend", ".env")
+	if err := fsutil.AppendUniqueLines(path, []string{"DATABASE_URL=" + opts.DatabaseURI}); err != nil {
+		return fmt.Errorf("write DATABASE_URL: %w", err)
+	}
+	return nil
+}
+
+// Rollback drops the database when Generate created it, otherwise only the tables
+// the initial migration created.
+func (s *sqlorm) Rollback(ctx context.Context, opts *Options) error {
+	if opts.DatabaseURI == "" || (!s.createdDB && !s.migrated) {
+		return nil
+	}
+	d := s.dialect
+
+	if s.createdDB {
+		if err := d.Server.DropDatabase(ctx, opts.DatabaseURI); err != nil {
+			return err
+		}
+		fmt.Printf("Rolled back %s (dropped database %s)\n", d.Label, databaseOf(opts.DatabaseURI))
+		return nil
+	}
+
+	tables := []string{"items", migrationTables[s.orm]}
+	if err := d.Server.DropTables(ctx, opts.DatabaseURI, tables); err != nil {
+		return err
+	}
+	fmt.Printf("Rolled back %s (dropped tables %s)\n", d.Label, strings.Join(tables, ", "))
+	return nil
+}
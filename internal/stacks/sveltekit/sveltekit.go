@@ -0,0 +1,127 @@
+package sveltekit
+
+import (
+	"context"
+	"fmt"
+	"path/filepath"
+	"strings"
+
+	"github.com/b-jonathan/taco/internal/execx"
+	"github.com/b-jonathan/taco/internal/fsutil"
+	"github.com/b-jonathan/taco/internal/nodepkg"
+	"github.com/b-jonathan/taco/internal/stacks"
+	"github.com/spf13/afero"
+)
+
+type Stack = stacks.Stack
+type Options = stacks.Options
+
+type sveltekit struct{}
+
+func New() Stack { return &sveltekit{} }
+
+func (sveltekit) Type() string { return "frontend" }
+
+func (sveltekit) Name() string { return "sveltekit" }
+
+func (sveltekit) Init(ctx context.Context, opts *Options) error {
+	if err := fsutil.Fs.MkdirAll(opts.ProjectRoot, 0o755); err != nil {
+		return fmt.Errorf("mkdir: %w", err)
+	}
+	// every question sv would ask is answered by a flag; pinned like create-vite
+	svFlags := []string{
+		"--yes",
+		"sv@0.9.8",
+		"create",
+		"frontend",
+		"--template", "minimal",
+		"--types", "ts",
+		"--no-add-ons",
+		"--install", "npm",
+	}
+	if err := execx.RunCmd(ctx, opts.ProjectRoot, "npx "+strings.Join(svFlags, " ")); err != nil {
+		return fmt.Errorf("sv create: %w", err)
+	}
+
+	frontendDir := filepath.Join(opts.ProjectRoot, "frontend")
+	frontendDeps := []string{
+		"eslint",
+		"@eslint/js",
+		"globals",
+		"typescript-eslint",
+		"eslint-plugin-svelte",
+		"eslint-config-prettier",
+		"prettier",
+		"prettier-plugin-svelte",
+	}
+	if err := execx.RunCmd(ctx, frontendDir, "npm install -D "+strings.Join(frontendDeps, " ")); err != nil {
+		return fmt.Errorf("npm install dev deps: %w", err)
+	}
+	return nil
+}
+
+func (sveltekit) Generate(ctx context.Context, opts *Options) error {
+	frontendDir := filepath.Join(opts.ProjectRoot, "frontend")
+
+	if err := fsutil.GenerateFromTemplateDir("sveltekit", frontendDir); err != nil {
+		return fmt.Errorf("generate sveltekit templates: %w", err)
+	}
+
+	packageParams := nodepkg.InitPackageParams{
+		Name: "frontend",
+		Scripts: map[string]string{
+			"lint-check": "eslint . && prettier --check .",
+			"lint-fix":   "(eslint . --fix || true) && prettier --write .",
+		}}
+
+	if err := nodepkg.InitPackage(frontendDir, packageParams); err != nil {
+		return fmt.Errorf("init sveltekit package.json: %w", err)
+	}
+
+	return nil
+}
+
+func (sveltekit) Post(ctx context.Context, opts *Options) error {
+	envPath := filepath.Join(opts.ProjectRoot, "frontend", ".env")
+	if err := fsutil.EnsureFile(envPath); err != nil {
+		return fmt.Errorf("ensure .env: %w", err)
+	}
+	content := "PUBLIC_BACKEND_URL=" + opts.BackendURL + "\n"
+	if err := afero.WriteFile(fsutil.Fs, envPath, []byte(content), 0o644); err != nil {
+		return fmt.Errorf("write %s: %w", envPath, err)
+	}
+	return nil
+}
+
+// Excludes a container image: docker has no Dockerfile template for SvelteKit.
+func (sveltekit) Excludes() []string {
+	return []string{stacks.ContainerImage}
+}
+
+// Provides the Firebase sign-in pages under templates/firebase/sveltekit.
+func (sveltekit) Provides() []string {
+	return []string{stacks.FirebaseUI}
+}
+
+func (sveltekit) GitIgnore(opts *Options) []string {
+	return []string{"frontend/node_modules/", "frontend/.svelte-kit/", "frontend/build/", "frontend/.env", "frontend/.env.*"}
+}
+
+func (sveltekit) Services(ctx context.Context, opts *Options) ([]stacks.Service, error) {
+	return []stacks.Service{{
+		Name: "frontend",
+		Dir:  filepath.Join(opts.ProjectRoot, "frontend"),
+		Cmd:  "npm run dev",
+		Port: 3000,
+	}}, nil
+}
+
+func (sveltekit) Rollback(ctx context.Context, opts *Options) error {
+	frontendDir := filepath.Join(opts.ProjectRoot, "frontend")
+
+	if err := fsutil.RemoveDir(frontendDir); err != nil {
+		return fmt.Errorf("remove frontend dir: %w", err)
+	}
+
+	return nil
+}
@@ -2,5 +2,5 @@ package templates
 
 import "embed"
 
-//go:embed express/* firebase/* mongodb/* nextjs/*
+//go:embed express/* fastify/* nestjs/* go/* all:fastapi all:hono firebase/* mongodb/* mysql/* postgres/* sql/* redis/* sqlite/* nextjs/* vite-react/* sveltekit/* nuxt/* all:astro githubactions/* repofiles/* hooks/* all:docker
 var FS embed.FS
@@ -23,6 +23,32 @@ type Options struct {
 	Backend     string
 	BackendURL  string
 	Database    string
+	Cache       string
 	Port        int
 	DatabaseURI string
+	CacheURI    string
+	// DefaultBranch is the branch the scaffold is committed on; CI runs on pushes to it.
+	DefaultBranch string
+}
+
+// Ignorer is implemented by stacks that contribute patterns, relative to the
+// project root, to the combined root .gitignore.
+type Ignorer interface {
+	GitIgnore(opts *Options) []string
+}
+
+// Service is a long-running process started by `taco dev`.
+type Service struct {
+	Name string
+	Dir  string
+	Cmd  string
+	Port int
+	// Reuse treats a port that is already listening as a running instance
+	// of the service instead of a conflict (e.g. a local database).
+	Reuse bool
+}
+
+// Runner is implemented by stacks that contribute services to `taco dev`.
+type Runner interface {
+	Services(ctx context.Context, opts *Options) ([]Service, error)
 }
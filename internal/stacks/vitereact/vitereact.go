@@ -0,0 +1,118 @@
+package vitereact
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/b-jonathan/taco/internal/execx"
+	"github.com/b-jonathan/taco/internal/fsutil"
+	"github.com/b-jonathan/taco/internal/nodepkg"
+	"github.com/b-jonathan/taco/internal/stacks"
+	"github.com/spf13/afero"
+)
+
+type Stack = stacks.Stack
+type Options = stacks.Options
+
+type viteReact struct{}
+
+func New() Stack { return &viteReact{} }
+
+func (viteReact) Type() string { return "frontend" }
+
+func (viteReact) Name() string { return "vite-react" }
+
+func (viteReact) Init(ctx context.Context, opts *Options) error {
+	if err := fsutil.Fs.MkdirAll(opts.ProjectRoot, 0o755); err != nil {
+		return fmt.Errorf("mkdir: %w", err)
+	}
+	// pinned so the scaffold never stops at a new interactive question
+	if err := execx.RunCmd(ctx, opts.ProjectRoot, "npx --yes create-vite@6.5.0 frontend --template react-ts"); err != nil {
+		return fmt.Errorf("create-vite: %w", err)
+	}
+
+	frontendDir := filepath.Join(opts.ProjectRoot, "frontend")
+	if err := execx.RunCmd(ctx, frontendDir, "npm install"); err != nil {
+		return fmt.Errorf("npm install: %w", err)
+	}
+	frontendDeps := []string{
+		"eslint",
+		"@eslint/js",
+		"globals",
+		"typescript-eslint",
+		"eslint-plugin-react-hooks",
+		"eslint-plugin-react-refresh",
+		"eslint-config-prettier",
+		"prettier",
+	}
+	if err := execx.RunCmd(ctx, frontendDir, "npm install -D "+strings.Join(frontendDeps, " ")); err != nil {
+		return fmt.Errorf("npm install dev deps: %w", err)
+	}
+	return nil
+}
+
+func (viteReact) Generate(ctx context.Context, opts *Options) error {
+	frontendDir := filepath.Join(opts.ProjectRoot, "frontend")
+
+	if err := fsutil.GenerateFromTemplateDir("vite-react", frontendDir); err != nil {
+		return fmt.Errorf("generate vite-react templates: %w", err)
+	}
+
+	// replaced by eslint.config.mjs and the new App.tsx
+	for _, name := range []string{"eslint.config.js", filepath.Join("src", "App.css")} {
+		if err := fsutil.Fs.Remove(filepath.Join(frontendDir, name)); err != nil && !os.IsNotExist(err) {
+			return fmt.Errorf("remove %s: %w", name, err)
+		}
+	}
+
+	packageParams := nodepkg.InitPackageParams{
+		Name: "frontend",
+		Scripts: map[string]string{
+			"lint-check": "eslint . && prettier --check .",
+			"lint-fix":   "(eslint . --fix || true) && prettier --write .",
+		}}
+
+	if err := nodepkg.InitPackage(frontendDir, packageParams); err != nil {
+		return fmt.Errorf("init vite-react package.json: %w", err)
+	}
+
+	return nil
+}
+
+func (viteReact) Post(ctx context.Context, opts *Options) error {
+	envPath := filepath.Join(opts.ProjectRoot, "frontend", ".env.local")
+	if err := fsutil.EnsureFile(envPath); err != nil {
+		return fmt.Errorf("ensure .env.local: %w", err)
+	}
+	content := "VITE_BACKEND_URL=" + opts.BackendURL + "\n"
+	if err := afero.WriteFile(fsutil.Fs, envPath, []byte(content), 0o644); err != nil {
+		return fmt.Errorf("write %s: %w", envPath, err)
+	}
+	return nil
+}
+
+func (viteReact) GitIgnore(opts *Options) []string {
+	return []string{"frontend/node_modules/", "frontend/dist/", "frontend/.env*.local"}
+}
+
+func (viteReact) Services(ctx context.Context, opts *Options) ([]stacks.Service, error) {
+	return []stacks.Service{{
+		Name: "frontend",
+		Dir:  filepath.Join(opts.ProjectRoot, "frontend"),
+		Cmd:  "npm run dev",
+		Port: 3000,
+	}}, nil
+}
+
+func (viteReact) Rollback(ctx context.Context, opts *Options) error {
+	frontendDir := filepath.Join(opts.ProjectRoot, "frontend")
+
+	if err := fsutil.RemoveDir(frontendDir); err != nil {
+		return fmt.Errorf("remove frontend dir: %w", err)
+	}
+
+	return nil
+}
@@ -0,0 +1,127 @@
+// Package wire adds database (and similar) code to a generated backend.
+//
+// A stack keeps one template folder per backend it supports,
+// templates/<stack>/<backend>, with two parts:
+//   - files/: written into backend/ as-is (connection helpers, modules, ...)
+//   - snippets/: one template per anchor, named after it in lower case with
+//     dashes (database-import.tmpl fills "[DATABASE IMPORT]"), inserted into
+//     the backend's entry file
+//
+// Both are rendered with Data.
+package wire
+
+import (
+	"context"
+	"fmt"
+	"io/fs"
+	"path"
+	"path/filepath"
+	"regexp"
+	"strings"
+
+	"github.com/b-jonathan/taco/internal/execx"
+	"github.com/b-jonathan/taco/internal/fsutil"
+	"github.com/b-jonathan/taco/internal/stacks"
+	"github.com/b-jonathan/taco/internal/stacks/templates"
+	"github.com/spf13/afero"
+)
+
+// entries is the file of each backend, relative to backend/, that holds the
+// anchor comments.
+var entries = map[string]string{
+	"express": "src/index.ts",
+	"fastify": "src/index.ts",
+	"nestjs":  "src/app.module.ts",
+	"hono":    "src/app.ts",
+	"go":      "cmd/server/main.go",
+	"fastapi": "app/main.py",
+}
+
+// Data is passed to the files and snippets templates.
+type Data struct {
+	AppName string
+	Port    int
+	Module  string // Go module path from backend/go.mod; empty for other backends
+}
+
+// Entry returns the path of the backend's entry file under backendDir.
+func Entry(backend, backendDir string) (string, error) {
+	rel, ok := entries[backend]
+	if !ok {
+		return "", fmt.Errorf("backend '%s' has no entry file for wiring", backend)
+	}
+	return filepath.Join(backendDir, filepath.FromSlash(rel)), nil
+}
+
+// runCmd runs Format's command; tests replace it.
+var runCmd = execx.RunCmd
+
+// Format runs Prettier in backend/ over paths and the backend's entry file,
+// so code written from templates follows the backend's own Prettier config.
+// paths are relative to backend/.
+func Format(ctx context.Context, opts *stacks.Options, paths ...string) error {
+	rel, ok := entries[opts.Backend]
+	if !ok {
+		return fmt.Errorf("backend '%s' has no entry file for wiring", opts.Backend)
+	}
+	backendDir := filepath.Join(opts.ProjectRoot, "backend")
+	args := append(append([]string{"npx", "prettier", "--write"}, paths...), rel)
+	if err := runCmd(ctx, backendDir, strings.Join(args, " ")); err != nil {
+		return fmt.Errorf("prettier: %w", err)
+	}
+	return nil
+}
+
+// Apply writes templates/<stack>/<opts.Backend>/files into backend/ and
+// inserts each snippet at its anchor in the backend's entry file. Running it
+// twice changes nothing.
+func Apply(stack string, opts *stacks.Options) error {
+	backend := opts.Backend
+	backendDir := filepath.Join(opts.ProjectRoot, "backend")
+	data := Data{AppName: opts.AppName, Port: opts.Port, Module: goModule(backendDir)}
+
+	root := path.Join(stack, backend)
+	if _, err := fs.Stat(templates.FS, path.Join(root, "files")); err == nil {
+		if err := fsutil.GenerateFromTemplateDirData(path.Join(root, "files"), backendDir, data); err != nil {
+			return fmt.Errorf("generate %s files: %w", root, err)
+		}
+	}
+
+	snippets, err := fs.ReadDir(templates.FS, path.Join(root, "snippets"))
+	if err != nil {
+		return nil
+	}
+	entry, err := Entry(backend, backendDir)
+	if err != nil {
+		return err
+	}
+	for _, s := range snippets {
+		name := strings.TrimSuffix(s.Name(), ".tmpl")
+		text, err := fsutil.RenderTemplateData(path.Join(root, "snippets", s.Name()), data)
+		if err != nil {
+			return err
+		}
+		if err := fsutil.InsertAtAnchor(entry, Anchor(name), string(text)); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// Anchor turns a snippet name into its anchor: database-route -> [DATABASE ROUTE].
+func Anchor(name string) string {
+	return "[" + strings.ToUpper(strings.ReplaceAll(name, "-", " ")) + "]"
+}
+
+var moduleLine = regexp.MustCompile(`(?m)^module\s+"?([^"\s]+)"?`)
+
+func goModule(backendDir string) string {
+	buf, err := afero.ReadFile(fsutil.Fs, filepath.Join(backendDir, "go.mod"))
+	if err != nil {
+		return ""
+	}
+	if m := moduleLine.FindSubmatch(buf); m != nil {
+		return string(m[1])
+	}
+	return ""
+}
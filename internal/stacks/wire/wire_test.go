@@ -0,0 +1,71 @@
+package wire
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/b-jonathan/taco/internal/execx"
+	"github.com/b-jonathan/taco/internal/stacks"
+)
+
+func TestFormat(t *testing.T) {
+	tests := []struct {
+		backend string
+		want    string
+	}{
+		{"express", "npx prettier --write src/db src/index.ts"},
+		{"fastify", "npx prettier --write src/db src/index.ts"},
+		{"nestjs", "npx prettier --write src/db src/app.module.ts"},
+		{"hono", "npx prettier --write src/db src/app.ts"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.backend, func(t *testing.T) {
+			root := t.TempDir()
+			backendDir := filepath.Join(root, "backend")
+			entry := filepath.Join(backendDir, filepath.FromSlash(entries[tt.backend]))
+			if err := os.MkdirAll(filepath.Dir(entry), 0o755); err != nil {
+				t.Fatal(err)
+			}
+			if err := os.WriteFile(entry, nil, 0o644); err != nil {
+				t.Fatal(err)
+			}
+
+			var gotDir, gotCmd string
+			runCmd = func(_ context.Context, dir, cmd string) error {
+				gotDir, gotCmd = dir, cmd
+				return nil
+			}
+			t.Cleanup(func() { runCmd = execx.RunCmd })
+
+			if err := Format(context.Background(), &stacks.Options{ProjectRoot: root, Backend: tt.backend}, "src/db"); err != nil {
+				t.Fatal(err)
+			}
+			if gotDir != backendDir {
+				t.Errorf("dir = %q, want %q", gotDir, backendDir)
+			}
+			if gotCmd != tt.want {
+				t.Errorf("cmd = %q, want %q", gotCmd, tt.want)
+			}
+			// prettier resolves the paths from its working directory
+			args := strings.Fields(gotCmd)
+			if _, err := os.Stat(filepath.Join(gotDir, args[len(args)-1])); err != nil {
+				t.Errorf("entry path doesn't resolve from %s: %v", gotDir, err)
+			}
+		})
+	}
+}
+
+func TestFormatUnknownBackend(t *testing.T) {
+	runCmd = func(context.Context, string, string) error {
+		t.Fatal("prettier run for a backend without an entry file")
+		return nil
+	}
+	t.Cleanup(func() { runCmd = execx.RunCmd })
+
+	if err := Format(context.Background(), &stacks.Options{ProjectRoot: t.TempDir(), Backend: "rails"}, "src/db"); err == nil {
+		t.Fatal("expected an error")
+	}
+}